@@ -0,0 +1,190 @@
+// Package config reads the templ project configuration file, which sets the defaults for the flags
+// of the templ commands.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+)
+
+// FileNames are the names of the configuration files, which are searched for in the working
+// directory and each of its parents.
+var FileNames = []string{"templ.toml", "templ.json"}
+
+// Config of a templ project.
+type Config struct {
+	// FileName of the configuration file. Relative paths in the file are relative to its directory.
+	FileName string `json:"-" toml:"-"`
+	// WorkerCount is the number of files processed in parallel by generate, fmt and migrate.
+	WorkerCount int `json:"workerCount" toml:"workerCount"`
+	// Exclude is a list of glob patterns of files and directories skipped by generate, fmt and migrate.
+	Exclude  []string `json:"exclude" toml:"exclude"`
+	Generate Generate `json:"generate" toml:"generate"`
+	Fmt      Fmt      `json:"fmt" toml:"fmt"`
+	LSP      LSP      `json:"lsp" toml:"lsp"`
+	Migrate  Migrate  `json:"migrate" toml:"migrate"`
+}
+
+// Generate is the configuration of templ generate.
+type Generate struct {
+	Path                    string   `json:"path" toml:"path"`
+	WorkerCount             int      `json:"workerCount" toml:"workerCount"`
+	Exclude                 []string `json:"exclude" toml:"exclude"`
+	Format                  string   `json:"format" toml:"format"`
+	SourceMapVisualisations bool     `json:"sourceMapVisualisations" toml:"sourceMapVisualisations"`
+	LineDirectives          bool     `json:"lineDirectives" toml:"lineDirectives"`
+	Cache                   string   `json:"cache" toml:"cache"`
+	Clean                   bool     `json:"clean" toml:"clean"`
+}
+
+// Fmt is the configuration of templ fmt. The output format can't be set, since it can't be combined
+// with formatting stdin, which is how editors run templ fmt.
+type Fmt struct {
+	WorkerCount int      `json:"workerCount" toml:"workerCount"`
+	Exclude     []string `json:"exclude" toml:"exclude"`
+}
+
+// LSP is the configuration of templ lsp.
+type LSP struct {
+	Log           string `json:"log" toml:"log"`
+	GoplsLog      string `json:"goplsLog" toml:"goplsLog"`
+	GoplsRPCTrace bool   `json:"goplsRPCTrace" toml:"goplsRPCTrace"`
+}
+
+// Migrate is the configuration of templ migrate.
+type Migrate struct {
+	Path        string   `json:"path" toml:"path"`
+	WorkerCount int      `json:"workerCount" toml:"workerCount"`
+	Exclude     []string `json:"exclude" toml:"exclude"`
+	Format      string   `json:"format" toml:"format"`
+}
+
+// Find the configuration file in the directory, or the closest of its parents, and read it. If there
+// isn't a configuration file, an empty Config is returned.
+func Find(dir string) (c Config, err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return c, err
+	}
+	for {
+		var found []string
+		for _, name := range FileNames {
+			fileName := filepath.Join(dir, name)
+			if _, err = os.Stat(fileName); err == nil {
+				found = append(found, fileName)
+			}
+		}
+		if len(found) > 1 {
+			return c, fmt.Errorf("found %s and %s, remove one of them", found[0], found[1])
+		}
+		if len(found) == 1 {
+			return Read(found[0])
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return c, nil
+		}
+		dir = parent
+	}
+}
+
+// Read the configuration file. The format is chosen by the file's extension. Unknown settings are
+// an error, so that typos aren't silently ignored.
+func Read(fileName string) (c Config, err error) {
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		return c, fmt.Errorf("%s read error: %w", fileName, err)
+	}
+	switch filepath.Ext(fileName) {
+	case ".toml":
+		md, err := toml.Decode(string(b), &c)
+		if err != nil {
+			return c, fmt.Errorf("%s parsing error: %w", fileName, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return c, fmt.Errorf("%s parsing error: unknown setting %q", fileName, undecoded[0].String())
+		}
+	case ".json":
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.DisallowUnknownFields()
+		if err = d.Decode(&c); err != nil {
+			return c, fmt.Errorf("%s parsing error: %w", fileName, err)
+		}
+	default:
+		return c, errors.New("unknown configuration file format: " + fileName)
+	}
+	c.FileName = fileName
+	return c, nil
+}
+
+// Flags returns the settings for the command as flag values, keyed by the name of the flag. Settings
+// for the command take precedence over settings shared by all commands, and settings that aren't set
+// are left out.
+func (c Config) Flags(command string) (flags map[string][]string) {
+	flags = make(map[string][]string)
+	setString := func(name, value string) {
+		if value != "" {
+			flags[name] = []string{value}
+		}
+	}
+	setPath := func(name, value string) {
+		if value != "" && !filepath.IsAbs(value) {
+			value = filepath.Join(filepath.Dir(c.FileName), value)
+		}
+		setString(name, value)
+	}
+	setBool := func(name string, value bool) {
+		if value {
+			flags[name] = []string{"true"}
+		}
+	}
+	setInt := func(name string, value int) {
+		if value != 0 {
+			flags[name] = []string{strconv.Itoa(value)}
+		}
+	}
+	setStrings := func(name string, values []string) {
+		if len(values) > 0 {
+			flags[name] = values
+		}
+	}
+	// fmt uses -w to write files in place, so its worker count flag has a different name.
+	shared := func(workerCountFlag string, workerCount int, exclude []string) {
+		setInt(workerCountFlag, c.WorkerCount)
+		setInt(workerCountFlag, workerCount)
+		setStrings("exclude", c.Exclude)
+		setStrings("exclude", exclude)
+	}
+	switch command {
+	case "generate":
+		g := c.Generate
+		shared("w", g.WorkerCount, g.Exclude)
+		setString("format", g.Format)
+		setPath("path", g.Path)
+		setBool("sourceMapVisualisations", g.SourceMapVisualisations)
+		setBool("lineDirectives", g.LineDirectives)
+		setPath("cache", g.Cache)
+		setBool("clean", g.Clean)
+	case "fmt":
+		f := c.Fmt
+		shared("workerCount", f.WorkerCount, f.Exclude)
+	case "lsp":
+		l := c.LSP
+		setPath("log", l.Log)
+		setPath("goplsLog", l.GoplsLog)
+		setBool("goplsRPCTrace", l.GoplsRPCTrace)
+	case "migrate":
+		m := c.Migrate
+		shared("w", m.WorkerCount, m.Exclude)
+		setString("format", m.Format)
+		setPath("path", m.Path)
+	}
+	return flags
+}
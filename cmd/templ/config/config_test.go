@@ -0,0 +1,153 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func writeFile(t *testing.T, fileName, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(fileName, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+const tomlConfig = `workerCount = 8
+exclude = ["testdata"]
+
+[generate]
+path = "components"
+lineDirectives = true
+cache = ".templ-cache.json"
+exclude = ["*_test.templ"]
+
+[fmt]
+workerCount = 2
+
+[lsp]
+log = "/tmp/templ.log"
+`
+
+const jsonConfig = `{
+	"workerCount": 8,
+	"exclude": ["testdata"],
+	"generate": {
+		"path": "components",
+		"lineDirectives": true,
+		"cache": ".templ-cache.json",
+		"exclude": ["*_test.templ"]
+	},
+	"fmt": {
+		"workerCount": 2
+	},
+	"lsp": {
+		"log": "/tmp/templ.log"
+	}
+}`
+
+func TestFind(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		contents string
+	}{
+		{name: "templ.toml", contents: tomlConfig},
+		{name: "templ.json", contents: jsonConfig},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, filepath.Join(dir, tt.name), tt.contents)
+			sub := filepath.Join(dir, "components", "header")
+			if err := os.MkdirAll(sub, 0755); err != nil {
+				t.Fatalf("failed to create directory: %v", err)
+			}
+			c, err := Find(sub)
+			if err != nil {
+				t.Fatalf("failed to find config: %v", err)
+			}
+			if c.FileName != filepath.Join(dir, tt.name) {
+				t.Errorf("expected the config in %s to be found, got %q", dir, c.FileName)
+			}
+			expected := map[string]map[string][]string{
+				"generate": {
+					"w":              {"8"},
+					"exclude":        {"*_test.templ"},
+					"path":           {filepath.Join(dir, "components")},
+					"lineDirectives": {"true"},
+					"cache":          {filepath.Join(dir, ".templ-cache.json")},
+				},
+				"fmt": {
+					"workerCount": {"2"},
+					"exclude":     {"testdata"},
+				},
+				"lsp": {
+					"log": {"/tmp/templ.log"},
+				},
+				"migrate": {
+					"w":       {"8"},
+					"exclude": {"testdata"},
+				},
+			}
+			for command, flags := range expected {
+				if diff := cmp.Diff(flags, c.Flags(command)); diff != "" {
+					t.Errorf("%s: %s", command, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestFindWithoutConfig(t *testing.T) {
+	c, err := Find(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string][]string{}, c.Flags("generate")); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestFindErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name: "both formats in the same directory",
+			files: map[string]string{
+				"templ.toml": "",
+				"templ.json": "{}",
+			},
+		},
+		{
+			name:  "unknown TOML settings",
+			files: map[string]string{"templ.toml": "[generate]\nlineDirective = true\n"},
+		},
+		{
+			name:  "unknown JSON settings",
+			files: map[string]string{"templ.json": `{"generate": {"lineDirective": true}}`},
+		},
+		{
+			name:  "invalid TOML",
+			files: map[string]string{"templ.toml": "[generate\n"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, contents := range tt.files {
+				writeFile(t, filepath.Join(dir, name), contents)
+			}
+			if _, err := Find(dir); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
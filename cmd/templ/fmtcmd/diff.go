@@ -0,0 +1,204 @@
+package fmtcmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change.
+const diffContextLines = 3
+
+type editKind int
+
+const (
+	editEqual editKind = iota
+	editDelete
+	editInsert
+)
+
+// edit of a line. a and b are the indexes of the line in the old and new files, or, for inserts
+// and deletes, the index the line would have in the file it's not in.
+type edit struct {
+	kind editKind
+	a, b int
+}
+
+// unifiedDiff returns a unified diff from a to b, or an empty string if they're equal.
+func unifiedDiff(aName, bName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	aLines, bLines := splitLines(a), splitLines(b)
+	edits := diffLines(aLines, bLines)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", aName, bName)
+	for _, h := range hunks(edits) {
+		var aCount, bCount int
+		for _, e := range h {
+			if e.kind != editInsert {
+				aCount++
+			}
+			if e.kind != editDelete {
+				bCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(h[0].a, aCount), hunkRange(h[0].b, bCount))
+		for _, e := range h {
+			switch e.kind {
+			case editEqual:
+				writeDiffLine(&sb, ' ', aLines[e.a])
+			case editDelete:
+				writeDiffLine(&sb, '-', aLines[e.a])
+			case editInsert:
+				writeDiffLine(&sb, '+', bLines[e.b])
+			}
+		}
+	}
+	return sb.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		// Empty ranges refer to the line before the change.
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func writeDiffLine(sb *strings.Builder, prefix rune, line string) {
+	sb.WriteRune(prefix)
+	sb.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		sb.WriteString("\n\\ No newline at end of file\n")
+	}
+}
+
+// splitLines splits s into lines, keeping the line endings.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// hunks groups the changes, with the unchanged lines around them.
+func hunks(edits []edit) (hunks [][]edit) {
+	start, end := -1, -1
+	for i, e := range edits {
+		if e.kind == editEqual {
+			continue
+		}
+		from, to := i-diffContextLines, i+diffContextLines+1
+		if from < 0 {
+			from = 0
+		}
+		if to > len(edits) {
+			to = len(edits)
+		}
+		if start >= 0 && from <= end {
+			end = to
+			continue
+		}
+		if start >= 0 {
+			hunks = append(hunks, edits[start:end])
+		}
+		start, end = from, to
+	}
+	if start >= 0 {
+		hunks = append(hunks, edits[start:end])
+	}
+	return hunks
+}
+
+// diffLines returns the shortest edit script from a to b.
+func diffLines(a, b []string) (edits []edit) {
+	// Remove the common prefix and suffix to reduce the work done by the diff algorithm.
+	var prefix int
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	var suffix int
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, edit{kind: editEqual, a: i, b: i})
+	}
+	for _, e := range myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		e.a += prefix
+		e.b += prefix
+		edits = append(edits, e)
+	}
+	for i := suffix; i > 0; i-- {
+		edits = append(edits, edit{kind: editEqual, a: len(a) - i, b: len(b) - i})
+	}
+	return edits
+}
+
+// myers implements the Myers diff algorithm, see "An O(ND) Difference Algorithm and Its Variations".
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	max := n + m
+	// v holds the furthest x reached on each diagonal k, offset by max.
+	v := make([]int, 2*max+2)
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[max+k-1] < v[max+k+1]) {
+				x = v[max+k+1]
+			} else {
+				x = v[max+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[max+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, n, m, max)
+			}
+		}
+	}
+	return nil
+}
+
+func backtrack(trace [][]int, n, m, max int) []edit {
+	var edits []edit
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[max+k-1] < v[max+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[max+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{kind: editEqual, a: x, b: y})
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{kind: editInsert, a: x, b: y - 1})
+			} else {
+				edits = append(edits, edit{kind: editDelete, a: x - 1, b: y})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
@@ -0,0 +1,81 @@
+package fmtcmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected string
+	}{
+		{
+			name:     "equal files have no diff",
+			a:        "a\nb\n",
+			b:        "a\nb\n",
+			expected: "",
+		},
+		{
+			name: "changed lines are shown with context",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			b:    "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			expected: "--- a\n+++ b\n" +
+				"@@ -2,7 +2,7 @@\n" +
+				" 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "changes that are far apart are shown in separate hunks",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			b:    "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			expected: "--- a\n+++ b\n" +
+				"@@ -1,4 +1,4 @@\n" +
+				"-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,4 @@\n" +
+				" 7\n 8\n 9\n-10\n+ten\n",
+		},
+		{
+			name: "changes that are close together are shown in the same hunk",
+			a:    "1\n2\n3\n4\n5\n",
+			b:    "one\n2\n3\n4\nfive\n",
+			expected: "--- a\n+++ b\n" +
+				"@@ -1,5 +1,5 @@\n" +
+				"-1\n+one\n 2\n 3\n 4\n-5\n+five\n",
+		},
+		{
+			name: "lines can be inserted into empty files",
+			a:    "",
+			b:    "a\n",
+			expected: "--- a\n+++ b\n" +
+				"@@ -0,0 +1 @@\n" +
+				"+a\n",
+		},
+		{
+			name: "missing newlines at the end of the file are shown",
+			a:    "a\nb",
+			b:    "a\nb\n",
+			expected: "--- a\n+++ b\n" +
+				"@@ -1,2 +1,2 @@\n" +
+				" a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name: "inserted and deleted lines are interleaved with unchanged lines",
+			a:    "a\nb\nc\nd\n",
+			b:    "a\nc\nd\ne\n",
+			expected: "--- a\n+++ b\n" +
+				"@@ -1,4 +1,4 @@\n" +
+				" a\n-b\n c\n d\n+e\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			actual := unifiedDiff("a", "b", tt.a, tt.b)
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -2,74 +2,246 @@ package fmtcmd
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"os"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/a-h/templ/cmd/templ/output"
 	"github.com/a-h/templ/cmd/templ/processor"
 	parser "github.com/a-h/templ/parser/v2"
 	"github.com/hashicorp/go-multierror"
 	"github.com/natefinch/atomic"
 )
 
-const workerCount = 4
+const defaultWorkerCount = 4
 
-func Run(args []string) (err error) {
-	if len(args) > 0 {
-		return formatDir(args[0])
-	}
-	return formatStdin()
+const stdinFileName = "<standard input>"
+
+type Arguments struct {
+	// Paths of files and directories to format. If empty, stdin is formatted.
+	Paths []string
+	// Check lists the files that aren't formatted, and returns an error if there are any.
+	Check bool
+	// Diff writes a unified diff of the changes that formatting would make.
+	Diff bool
+	// Write the formatted files in place. Files are written in place by default, unless Check, Diff
+	// or Stdout are set.
+	Write bool
+	// Stdout writes the formatted files to stdout.
+	Stdout bool
+	// Format of the output. Formats other than text can't be combined with Diff or Stdout.
+	Format output.Format
+	// Exclude is a list of glob patterns of files and directories to skip when formatting a
+	// directory, in addition to node_modules, vendor, .git and the patterns in its .templignore file.
+	Exclude []string
+	// WorkerCount is the number of files formatted in parallel.
+	WorkerCount int
 }
 
-func formatStdin() (err error) {
-	var bytes []byte
-	bytes, err = ioutil.ReadAll(os.Stdin)
+// ErrUnformatted is returned by Run in check mode, if any of the files aren't formatted.
+var ErrUnformatted = errors.New("files are not formatted")
+
+func Run(ctx context.Context, stdin io.Reader, stdout io.Writer, args Arguments) (err error) {
+	if args.WorkerCount == 0 {
+		args.WorkerCount = defaultWorkerCount
+	}
+	f := &formatter{
+		args:        args,
+		write:       args.Write || (!args.Check && !args.Diff && !args.Stdout),
+		stdout:      stdout,
+		out:         output.NewWriter(stdout, args.Format),
+		unformatted: make(map[string]struct{}),
+		outputs:     make(map[string][]byte),
+	}
+	if len(args.Paths) == 0 {
+		if args.Write {
+			return errors.New("stdin can't be written in place, pass the paths of files to format")
+		}
+		f.write = false
+		f.args.Stdout = (!args.Check && !args.Diff) || args.Stdout
+	}
+	if f.out.Format != output.FormatText && (args.Diff || f.args.Stdout) {
+		return fmt.Errorf("the %s output format can't be combined with the formatted output, use -check or -w", f.out.Format)
+	}
+	if len(args.Paths) == 0 {
+		start := time.Now()
+		err = f.formatReader(stdinFileName, stdin)
+		f.report(stdinFileName, time.Since(start), err)
+	} else {
+		for _, path := range args.Paths {
+			if pathErr := f.formatPath(ctx, path); pathErr != nil {
+				err = multierror.Append(err, pathErr)
+			}
+		}
+	}
+	if outputErr := f.writeOutputs(); outputErr != nil {
+		err = multierror.Append(err, outputErr)
+	}
 	if err != nil {
+		return err
+	}
+	if args.Check && len(f.unformatted) > 0 {
+		return ErrUnformatted
+	}
+	return nil
+}
+
+type formatter struct {
+	args Arguments
+	// write the formatted files in place.
+	write bool
+
+	// out writes progress messages, or the result of each file if the format isn't text.
+	out *output.Writer
+
+	// m protects unformatted and outputs, since files are formatted concurrently.
+	m           sync.Mutex
+	stdout      io.Writer
+	unformatted map[string]struct{}
+	// outputs are written to stdout once all files are formatted, keyed by file name.
+	outputs map[string][]byte
+}
+
+// progress returns true if progress messages can be written to stdout without mixing them with the
+// formatted output.
+func (f *formatter) progress() bool {
+	return !f.args.Check && !f.args.Diff && !f.args.Stdout
+}
+
+// report the result of formatting a file, in formats other than text.
+func (f *formatter) report(fileName string, d time.Duration, err error) {
+	if f.out.Format == output.FormatText {
 		return
 	}
-	t, err := parser.ParseString(string(bytes))
 	if err != nil {
-		return fmt.Errorf("parsing error: %w", err)
+		f.out.File(fileName, d, output.StatusError, err)
+		return
 	}
-	err = t.Write(os.Stdout)
-	if err != nil {
-		return fmt.Errorf("formatting error: %w", err)
+	status := output.StatusOK
+	f.m.Lock()
+	if _, ok := f.unformatted[fileName]; ok && f.args.Check {
+		status = output.StatusUnformatted
 	}
-	return nil
+	f.m.Unlock()
+	f.out.File(fileName, d, status, nil)
 }
 
-func formatDir(dir string) (err error) {
+func (f *formatter) formatPath(ctx context.Context, path string) (err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		start := time.Now()
+		err = f.formatFile(path)
+		f.report(path, time.Since(start), err)
+		return err
+	}
 	start := time.Now()
 	results := make(chan processor.Result)
-	go processor.Process(".", format, workerCount, results)
+	go processor.Process(ctx, path, f.formatFile, f.args.WorkerCount, results, processor.Options{Exclude: f.args.Exclude})
 	var successCount, errorCount int
 	for r := range results {
+		f.report(r.FileName, r.Duration, r.Error)
 		if r.Error != nil {
 			err = multierror.Append(err, fmt.Errorf("%s: %w", r.FileName, r.Error))
 			errorCount++
 			continue
 		}
-		fmt.Printf("%s complete in %v\n", r.FileName, r.Duration)
+		if f.progress() {
+			f.out.Textf("%s complete in %v\n", r.FileName, r.Duration)
+		}
 		successCount++
 	}
-	fmt.Printf("Formatted %d templates with %d errors in %s\n", successCount+errorCount, errorCount, time.Since(start))
+	if f.progress() {
+		f.out.Textf("Formatted %d templates with %d errors in %s\n", successCount+errorCount, errorCount, time.Since(start))
+	}
 	return
 }
 
-func format(fileName string) (err error) {
+func (f *formatter) formatFile(fileName string) (err error) {
+	src, err := os.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("%s read error: %w", fileName, err)
+	}
+	// Parse the file, rather than the contents, so that the default package name is based on its directory.
 	t, err := parser.Parse(fileName)
 	if err != nil {
 		return fmt.Errorf("%s parsing error: %w", fileName, err)
 	}
+	return f.format(fileName, src, t)
+}
+
+func (f *formatter) formatReader(fileName string, r io.Reader) (err error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("%s read error: %w", fileName, err)
+	}
+	t, err := parser.ParseString(string(src))
+	if err != nil {
+		return fmt.Errorf("%s parsing error: %w", fileName, err)
+	}
+	return f.format(fileName, src, t)
+}
+
+func (f *formatter) format(fileName string, src []byte, t parser.TemplateFile) (err error) {
 	w := new(bytes.Buffer)
 	err = t.Write(w)
 	if err != nil {
 		return fmt.Errorf("%s formatting error: %w", fileName, err)
 	}
-	err = atomic.WriteFile(fileName, w)
-	if err != nil {
-		return fmt.Errorf("%s file write error: %w", fileName, err)
+	formatted := w.Bytes()
+	changed := !bytes.Equal(src, formatted)
+	f.output(fileName, src, formatted, changed)
+	if f.write && changed {
+		err = atomic.WriteFile(fileName, bytes.NewReader(formatted))
+		if err != nil {
+			return fmt.Errorf("%s file write error: %w", fileName, err)
+		}
 	}
 	return
 }
+
+// writeOutputs writes the output of each file in order of the file names, so that it doesn't depend on
+// the order the files were formatted in.
+func (f *formatter) writeOutputs() (err error) {
+	fileNames := make([]string, 0, len(f.outputs))
+	for fileName := range f.outputs {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		if _, err = f.stdout.Write(f.outputs[fileName]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// output records whether the file is formatted, and the output for the file, which is written later
+// by writeOutputs.
+func (f *formatter) output(fileName string, src, formatted []byte, changed bool) {
+	var b bytes.Buffer
+	if f.args.Check && changed && f.out.Format == output.FormatText {
+		b.WriteString(fileName + "\n")
+	}
+	if f.args.Diff && changed {
+		b.WriteString(unifiedDiff(fileName+".orig", fileName, string(src), string(formatted)))
+	}
+	if f.args.Stdout {
+		b.Write(formatted)
+	}
+	f.m.Lock()
+	defer f.m.Unlock()
+	if changed {
+		f.unformatted[fileName] = struct{}{}
+	}
+	if b.Len() > 0 {
+		f.outputs[fileName] = b.Bytes()
+	}
+}
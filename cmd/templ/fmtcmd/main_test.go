@@ -0,0 +1,217 @@
+package fmtcmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ/cmd/templ/output"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+const unformatted = `package test
+
+templ a() {
+<div>a</div>
+}
+`
+
+const formatted = `package test
+
+templ a() {
+	<div>a</div>
+}
+
+`
+
+func setup(t *testing.T) (dir string) {
+	dir = t.TempDir()
+	files := map[string]string{
+		"formatted.templ":       formatted,
+		"unformatted.templ":     unformatted,
+		"sub/unformatted.templ": unformatted,
+	}
+	for name, contents := range files {
+		fileName := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fileName, []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	return dir
+}
+
+func readFile(t *testing.T, fileName string) string {
+	t.Helper()
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	return string(b)
+}
+
+func TestRun(t *testing.T) {
+	t.Run("files in the path are written in place by default", func(t *testing.T) {
+		dir := setup(t)
+		var stdout strings.Builder
+		if err := Run(context.Background(), nil, &stdout, Arguments{Paths: []string{filepath.Join(dir, "sub")}}); err != nil {
+			t.Fatalf("failed to run: %v", err)
+		}
+		if diff := cmp.Diff(formatted, readFile(t, filepath.Join(dir, "sub", "unformatted.templ"))); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(unformatted, readFile(t, filepath.Join(dir, "unformatted.templ"))); diff != "" {
+			t.Errorf("expected files outside of the path to be unchanged: %s", diff)
+		}
+	})
+	t.Run("single files can be formatted", func(t *testing.T) {
+		dir := setup(t)
+		var stdout strings.Builder
+		if err := Run(context.Background(), nil, &stdout, Arguments{Paths: []string{filepath.Join(dir, "unformatted.templ")}}); err != nil {
+			t.Fatalf("failed to run: %v", err)
+		}
+		if diff := cmp.Diff(formatted, readFile(t, filepath.Join(dir, "unformatted.templ"))); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(unformatted, readFile(t, filepath.Join(dir, "sub", "unformatted.templ"))); diff != "" {
+			t.Errorf("expected other files to be unchanged: %s", diff)
+		}
+	})
+	t.Run("check lists unformatted files without changing them", func(t *testing.T) {
+		dir := setup(t)
+		var stdout strings.Builder
+		err := Run(context.Background(), nil, &stdout, Arguments{Paths: []string{dir}, Check: true})
+		if !errors.Is(err, ErrUnformatted) {
+			t.Errorf("expected ErrUnformatted, got %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		expected := []string{
+			filepath.Join(dir, "sub", "unformatted.templ"),
+			filepath.Join(dir, "unformatted.templ"),
+		}
+		if diff := cmp.Diff(expected, lines); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(unformatted, readFile(t, filepath.Join(dir, "unformatted.templ"))); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("check can list unformatted files for editors", func(t *testing.T) {
+		dir := setup(t)
+		var stdout strings.Builder
+		err := Run(context.Background(), nil, &stdout, Arguments{Paths: []string{dir}, Check: true, Format: output.FormatEditor})
+		if !errors.Is(err, ErrUnformatted) {
+			t.Errorf("expected ErrUnformatted, got %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		expected := []string{
+			filepath.Join(dir, "sub", "unformatted.templ") + ": unformatted",
+			filepath.Join(dir, "unformatted.templ") + ": unformatted",
+		}
+		if diff := cmp.Diff(expected, lines, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("the JSON format can't be combined with diffs", func(t *testing.T) {
+		dir := setup(t)
+		var stdout strings.Builder
+		if err := Run(context.Background(), nil, &stdout, Arguments{Paths: []string{dir}, Diff: true, Format: output.FormatJSON}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+	t.Run("check succeeds if all files are formatted", func(t *testing.T) {
+		dir := setup(t)
+		var stdout strings.Builder
+		if err := Run(context.Background(), nil, &stdout, Arguments{Paths: []string{filepath.Join(dir, "formatted.templ")}, Check: true}); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("expected no output, got %q", stdout.String())
+		}
+	})
+	t.Run("diff prints the changes without making them", func(t *testing.T) {
+		dir := setup(t)
+		fileName := filepath.Join(dir, "unformatted.templ")
+		var stdout strings.Builder
+		if err := Run(context.Background(), nil, &stdout, Arguments{Paths: []string{fileName}, Diff: true}); err != nil {
+			t.Fatalf("failed to run: %v", err)
+		}
+		if diff := cmp.Diff(unifiedDiff(fileName+".orig", fileName, unformatted, formatted), stdout.String()); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(unformatted, readFile(t, fileName)); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("diffs of a directory are printed in order of the file names", func(t *testing.T) {
+		dir := setup(t)
+		var stdout strings.Builder
+		if err := Run(context.Background(), nil, &stdout, Arguments{Paths: []string{dir}, Diff: true, WorkerCount: 2}); err != nil {
+			t.Fatalf("failed to run: %v", err)
+		}
+		sub, root := filepath.Join(dir, "sub", "unformatted.templ"), filepath.Join(dir, "unformatted.templ")
+		expected := unifiedDiff(sub+".orig", sub, unformatted, formatted) + unifiedDiff(root+".orig", root, unformatted, formatted)
+		if diff := cmp.Diff(expected, stdout.String()); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("diff can be combined with writing in place", func(t *testing.T) {
+		dir := setup(t)
+		fileName := filepath.Join(dir, "unformatted.templ")
+		var stdout strings.Builder
+		if err := Run(context.Background(), nil, &stdout, Arguments{Paths: []string{fileName}, Diff: true, Write: true}); err != nil {
+			t.Fatalf("failed to run: %v", err)
+		}
+		if stdout.Len() == 0 {
+			t.Error("expected a diff")
+		}
+		if diff := cmp.Diff(formatted, readFile(t, fileName)); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("stdout prints the formatted file without changing it", func(t *testing.T) {
+		dir := setup(t)
+		fileName := filepath.Join(dir, "unformatted.templ")
+		var stdout strings.Builder
+		if err := Run(context.Background(), nil, &stdout, Arguments{Paths: []string{fileName}, Stdout: true}); err != nil {
+			t.Fatalf("failed to run: %v", err)
+		}
+		if diff := cmp.Diff(formatted, stdout.String()); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(unformatted, readFile(t, fileName)); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("stdin is formatted to stdout", func(t *testing.T) {
+		var stdout strings.Builder
+		if err := Run(context.Background(), strings.NewReader(unformatted), &stdout, Arguments{}); err != nil {
+			t.Fatalf("failed to run: %v", err)
+		}
+		if diff := cmp.Diff(formatted, stdout.String()); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("stdin can be checked", func(t *testing.T) {
+		var stdout strings.Builder
+		err := Run(context.Background(), strings.NewReader(unformatted), &stdout, Arguments{Check: true})
+		if !errors.Is(err, ErrUnformatted) {
+			t.Errorf("expected ErrUnformatted, got %v", err)
+		}
+		if diff := cmp.Diff(stdinFileName+"\n", stdout.String()); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("stdin can't be written in place", func(t *testing.T) {
+		var stdout strings.Builder
+		if err := Run(context.Background(), strings.NewReader(unformatted), &stdout, Arguments{Write: true}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package generatecmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/a-h/templ"
+	"github.com/natefinch/atomic"
+)
+
+// cache records the hashes of templ files and the code generated from them, so that code generation
+// can be skipped, without parsing the templ file, if neither has changed.
+type cache struct {
+	fileName string
+	// dir that file names in the cache are relative to.
+	dir string
+
+	m       sync.Mutex
+	changed bool
+	data    cacheData
+}
+
+type cacheData struct {
+	// Key of the templ version and generator options that the code was generated with. The cache is
+	// only used if the key matches.
+	Key   string                `json:"key"`
+	Files map[string]cacheEntry `json:"files"`
+}
+
+type cacheEntry struct {
+	// Templ is the hash of the templ file.
+	Templ string `json:"templ"`
+	// Go is the hash of the generated code.
+	Go string `json:"go"`
+}
+
+// loadCache reads the cache file. If the file doesn't exist, can't be read, or was written for a
+// different version of templ or different options, an empty cache is returned.
+func loadCache(fileName string, lineDirectives bool) (c *cache, err error) {
+	dir, err := filepath.Abs(filepath.Dir(fileName))
+	if err != nil {
+		return nil, err
+	}
+	key, err := cacheKey(lineDirectives)
+	if err != nil {
+		return nil, err
+	}
+	c = &cache{
+		fileName: fileName,
+		dir:      dir,
+		data: cacheData{
+			Key:   key,
+			Files: make(map[string]cacheEntry),
+		},
+	}
+	b, err := os.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s read cache error: %w", fileName, err)
+	}
+	var data cacheData
+	if err = json.Unmarshal(b, &data); err != nil || data.Key != key || data.Files == nil {
+		// Start again with an empty cache.
+		c.changed = true
+		return c, nil
+	}
+	c.data = data
+	return c, nil
+}
+
+// cacheKey returns the key of the templ version and generator options. Development builds of templ can
+// generate different code without changing the version, so their key includes a hash of the binary.
+func cacheKey(lineDirectives bool) (string, error) {
+	version := templ.GetVersion()
+	key := fmt.Sprintf("templ@%s lineDirectives=%v", version, lineDirectives)
+	if !isDevelopmentVersion(version) {
+		return key, nil
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("cache error: %w", err)
+	}
+	h, err := hashFile(exe)
+	if err != nil {
+		return "", fmt.Errorf("cache error: %w", err)
+	}
+	return key + " build=" + h, nil
+}
+
+// isDevelopmentVersion returns true if the version is of a build of a local checkout, rather than a
+// release.
+func isDevelopmentVersion(version string) bool {
+	return version == "(devel)" || version == "unknown" || version == "" || strings.HasSuffix(version, "+dirty")
+}
+
+// compile generates code for the templ file, unless the templ file and the generated code haven't
+// changed since they were added to the cache.
+func (c *cache) compile(fileName string, compile func(fileName string) error) error {
+	key, err := c.key(fileName)
+	if err != nil {
+		return err
+	}
+	templHash, err := hashFile(fileName)
+	if err != nil {
+		return err
+	}
+	goHash, err := hashFile(goFileName(fileName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	c.m.Lock()
+	entry, ok := c.data.Files[key]
+	c.m.Unlock()
+	if ok && entry.Templ == templHash && entry.Go == goHash {
+		return nil
+	}
+	if err = compile(fileName); err != nil {
+		return err
+	}
+	if goHash, err = hashFile(goFileName(fileName)); err != nil {
+		return err
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.data.Files[key] = cacheEntry{Templ: templHash, Go: goHash}
+	c.changed = true
+	return nil
+}
+
+// key of the file in the cache, which is relative to the cache file, so that the cache can be
+// shared between machines.
+func (c *cache) key(fileName string) (string, error) {
+	abs, err := filepath.Abs(fileName)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(c.dir, abs)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// save the cache file, if it has changed. Entries for templ files that no longer exist are removed.
+func (c *cache) save() error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for key := range c.data.Files {
+		if _, err := os.Stat(filepath.Join(c.dir, filepath.FromSlash(key))); os.IsNotExist(err) {
+			delete(c.data.Files, key)
+			c.changed = true
+		}
+	}
+	if !c.changed {
+		return nil
+	}
+	b, err := json.MarshalIndent(c.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = atomic.WriteFile(c.fileName, bytes.NewReader(b)); err != nil {
+		return fmt.Errorf("%s write cache error: %w", c.fileName, err)
+	}
+	c.changed = false
+	return nil
+}
+
+func hashFile(fileName string) (string, error) {
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
@@ -0,0 +1,141 @@
+package generatecmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ"
+)
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	templFileName := filepath.Join(dir, "a.templ")
+	writeTemplate := func(t *testing.T, contents string) {
+		t.Helper()
+		if err := os.WriteFile(templFileName, []byte("package test\n\ntempl a() {\n\t<div>"+contents+"</div>\n}\n"), 0644); err != nil {
+			t.Fatalf("failed to write template: %v", err)
+		}
+	}
+	cacheFileName := filepath.Join(dir, ".templ-cache.json")
+	var compiled int
+	compileFile := func(fileName string) error {
+		compiled++
+		return compile(fileName, false, false)
+	}
+	expectCompiled := func(t *testing.T, c *cache, expected int) {
+		t.Helper()
+		compiled = 0
+		if err := c.compile(templFileName, compileFile); err != nil {
+			t.Fatalf("failed to compile: %v", err)
+		}
+		if compiled != expected {
+			t.Errorf("expected the file to be compiled %d times, got %d", expected, compiled)
+		}
+	}
+	load := func(t *testing.T, lineDirectives bool) *cache {
+		t.Helper()
+		c, err := loadCache(cacheFileName, lineDirectives)
+		if err != nil {
+			t.Fatalf("failed to load cache: %v", err)
+		}
+		return c
+	}
+
+	writeTemplate(t, "A")
+	c := load(t, false)
+	t.Run("files are compiled if they're not in the cache", func(t *testing.T) {
+		expectCompiled(t, c, 1)
+	})
+	t.Run("unchanged files are not compiled", func(t *testing.T) {
+		expectCompiled(t, c, 0)
+	})
+	t.Run("changed files are compiled", func(t *testing.T) {
+		writeTemplate(t, "B")
+		expectCompiled(t, c, 1)
+		expectCompiled(t, c, 0)
+	})
+	t.Run("files are compiled if the generated code has changed", func(t *testing.T) {
+		if err := os.Remove(goFileName(templFileName)); err != nil {
+			t.Fatalf("failed to remove generated code: %v", err)
+		}
+		expectCompiled(t, c, 1)
+	})
+	t.Run("the cache is saved", func(t *testing.T) {
+		if err := c.save(); err != nil {
+			t.Fatalf("failed to save cache: %v", err)
+		}
+		expectCompiled(t, load(t, false), 0)
+	})
+	t.Run("the cache is not used if the options are different", func(t *testing.T) {
+		expectCompiled(t, load(t, true), 1)
+	})
+	t.Run("invalid cache files are ignored", func(t *testing.T) {
+		if err := os.WriteFile(cacheFileName, []byte("{"), 0644); err != nil {
+			t.Fatalf("failed to write cache: %v", err)
+		}
+		expectCompiled(t, load(t, false), 1)
+	})
+}
+
+func TestCacheKey(t *testing.T) {
+	defer func(version string) {
+		templ.Version = version
+	}(templ.Version)
+	templ.Version = "v1.0.0"
+	key, err := cacheKey(false)
+	if err != nil {
+		t.Fatalf("failed to get key: %v", err)
+	}
+	if key != "templ@v1.0.0 lineDirectives=false" {
+		t.Errorf("unexpected key for a release: %q", key)
+	}
+	templ.Version = "v1.0.0+dirty"
+	if key, err = cacheKey(false); err != nil {
+		t.Fatalf("failed to get key: %v", err)
+	}
+	if !strings.Contains(key, " build=") {
+		t.Errorf("expected the key of a development build to include a hash of the binary, got %q", key)
+	}
+}
+
+func TestIsDevelopmentVersion(t *testing.T) {
+	for version, expected := range map[string]bool{
+		"v0.2.184":                                     false,
+		"v0.2.185-0.20230101000000-abcdef123456":       false,
+		"v0.2.185-0.20230101000000-abcdef123456+dirty": true,
+		"(devel)": true,
+		"unknown": true,
+	} {
+		if actual := isDevelopmentVersion(version); actual != expected {
+			t.Errorf("%s: expected %v, got %v", version, expected, actual)
+		}
+	}
+}
+
+func TestCompileKeepsUnchangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	templFileName := filepath.Join(dir, "a.templ")
+	if err := os.WriteFile(templFileName, []byte("package test\n\ntempl a() {\n\t<div>A</div>\n}\n"), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	if err := compile(templFileName, false, false); err != nil {
+		t.Fatalf("failed to compile: %v", err)
+	}
+	modTime := time.Unix(1, 0)
+	if err := os.Chtimes(goFileName(templFileName), modTime, modTime); err != nil {
+		t.Fatalf("failed to set file time: %v", err)
+	}
+	if err := compile(templFileName, false, false); err != nil {
+		t.Fatalf("failed to compile: %v", err)
+	}
+	info, err := os.Stat(goFileName(templFileName))
+	if err != nil {
+		t.Fatalf("failed to stat generated code: %v", err)
+	}
+	if !info.ModTime().Equal(modTime) {
+		t.Errorf("expected the unchanged file not to be written, but its modification time changed to %v", info.ModTime())
+	}
+}
@@ -0,0 +1,80 @@
+package generatecmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/a-h/templ/cmd/templ/processor"
+)
+
+// generatedFileSuffixes are the suffixes added to the name of a templ file, minus its .templ
+// extension, to name the files generated from it.
+var generatedFileSuffixes = []string{"_templ.go", "_templ_sourcemap.html"}
+
+// cleanPath removes the generated files in the path that don't have a templ file, e.g. because the
+// templ file was renamed or deleted.
+func cleanPath(ctx context.Context, path string, opts processor.Options) (removed []string, err error) {
+	err = processor.Walk(ctx, path, opts, func(currentPath string) error {
+		for _, suffix := range generatedFileSuffixes {
+			if !strings.HasSuffix(currentPath, suffix) {
+				continue
+			}
+			templFileName := strings.TrimSuffix(currentPath, suffix) + ".templ"
+			r, err := removeOrphans(templFileName)
+			removed = append(removed, r...)
+			return err
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// removeOrphans removes the files generated from the templ file, if the templ file doesn't exist.
+// Go files are only removed if they have the header added by templ.
+func removeOrphans(templFileName string) (removed []string, err error) {
+	if _, err = os.Stat(templFileName); !os.IsNotExist(err) {
+		return nil, err
+	}
+	base := strings.TrimSuffix(templFileName, ".templ")
+	for _, suffix := range generatedFileSuffixes {
+		fileName := base + suffix
+		if _, err = os.Stat(fileName); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return removed, err
+		}
+		if strings.HasSuffix(fileName, ".go") {
+			generated, err := isGeneratedByTempl(fileName)
+			if err != nil {
+				return removed, err
+			}
+			if !generated {
+				continue
+			}
+		}
+		if err = os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("%s remove file error: %w", fileName, err)
+		}
+		removed = append(removed, fileName)
+	}
+	return removed, nil
+}
+
+// isGeneratedByTempl returns true if the Go file starts with the "Code generated by templ" header.
+func isGeneratedByTempl(fileName string) (ok bool, err error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	return strings.HasPrefix(line, codeGeneratedCommentPrefix), nil
+}
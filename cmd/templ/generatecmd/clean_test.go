@@ -0,0 +1,62 @@
+package generatecmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/a-h/templ/cmd/templ/processor"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClean(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.templ":                   "package test\n",
+		"a_templ.go":                codeGeneratedCommentPrefix + "v0.0.1 DO NOT EDIT.\n\npackage test\n",
+		"a_templ_sourcemap.html":    "<html></html>",
+		"b_templ.go":                codeGeneratedCommentPrefix + "v0.0.1 DO NOT EDIT.\n\npackage test\n",
+		"b_templ_sourcemap.html":    "<html></html>",
+		"handwritten_templ.go":      "package test\n",
+		"sub/c_templ.go":            codeGeneratedCommentPrefix + "v0.0.1 DO NOT EDIT.\n\npackage sub\n",
+		"sub/c_templ_sourcemap.txt": "not generated",
+		"empty_templ.go":            "",
+		// Excluded directories aren't cleaned.
+		"vendor/d_templ.go": codeGeneratedCommentPrefix + "v0.0.1 DO NOT EDIT.\n\npackage vendor\n",
+	}
+	for name, contents := range files {
+		fileName := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fileName, []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	removed, err := cleanPath(context.Background(), dir, processor.Options{})
+	if err != nil {
+		t.Fatalf("failed to clean: %v", err)
+	}
+	for i, fileName := range removed {
+		removed[i], _ = filepath.Rel(dir, fileName)
+	}
+	sort.Strings(removed)
+	expected := []string{"b_templ.go", "b_templ_sourcemap.html", filepath.Join("sub", "c_templ.go")}
+	if diff := cmp.Diff(expected, removed); diff != "" {
+		t.Error(diff)
+	}
+	for name := range files {
+		_, err := os.Stat(filepath.Join(dir, name))
+		wasRemoved := os.IsNotExist(err)
+		var expectRemoved bool
+		for _, r := range expected {
+			expectRemoved = expectRemoved || r == filepath.FromSlash(name)
+		}
+		if wasRemoved != expectRemoved {
+			t.Errorf("%s: expected removed to be %v, got %v", name, expectRemoved, wasRemoved)
+		}
+	}
+}
@@ -1,22 +1,29 @@
 package generatecmd
 
 import (
-	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"html"
 	"io"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/a-h/templ/cmd/templ/generatecmd/proxy"
+	"github.com/a-h/templ/cmd/templ/output"
 	"github.com/a-h/templ/cmd/templ/processor"
 	"github.com/a-h/templ/generator"
 	"github.com/a-h/templ/parser/v2"
 	"github.com/hashicorp/go-multierror"
+	"github.com/natefinch/atomic"
 )
 
 type Arguments struct {
@@ -24,70 +31,187 @@ type Arguments struct {
 	Path                            string
 	WorkerCount                     int
 	GenerateSourceMapVisualisations bool
+	LineDirectives                  bool
+	Watch                           bool
+	// Proxy is the URL of the app to add live reload to, e.g. http://localhost:8080.
+	Proxy     string
+	ProxyPort int
+	// Command starts the app, and is restarted after code is generated.
+	Command string
+	// Verify checks that the generated code is up to date, without writing any files.
+	Verify bool
+	// Clean removes generated files that don't have a templ file. In watch mode, the generated files
+	// are also removed when a templ file is removed.
+	Clean bool
+	// Cache is the name of a file used to record the hashes of templ files and generated code, so that
+	// unchanged files can be skipped without being parsed. It's not used if
+	// GenerateSourceMapVisualisations is set.
+	Cache string
+	// Format of the output.
+	Format output.Format
+	// Exclude is a list of glob patterns of files and directories to skip, in addition to
+	// node_modules, vendor, .git and the patterns in the .templignore file in the path.
+	Exclude []string
 }
 
 var defaultWorkerCount = runtime.NumCPU()
 
-func Run(args Arguments) (err error) {
-	if args.FileName != "" {
-		return processSingleFile(args.FileName, args.GenerateSourceMapVisualisations)
-	}
+// DefaultProxyPort is the port the live reload proxy listens on, if ProxyPort isn't set.
+const DefaultProxyPort = 7331
+
+func Run(ctx context.Context, args Arguments) (err error) {
 	if args.WorkerCount == 0 {
 		args.WorkerCount = defaultWorkerCount
 	}
-	return processPath(args.Path, args.GenerateSourceMapVisualisations, args.WorkerCount)
+	if args.ProxyPort == 0 {
+		args.ProxyPort = DefaultProxyPort
+	}
+	out := output.NewWriter(os.Stdout, args.Format)
+	opts := processor.Options{Exclude: args.Exclude}
+	if args.Verify {
+		return verifyPath(ctx, out, args.Path, args.FileName, args.LineDirectives, args.WorkerCount, opts)
+	}
+	if args.Clean {
+		if err = clean(ctx, out, args.Path, opts); err != nil {
+			return err
+		}
+	}
+	compileFile := func(fileName string) error {
+		return compile(fileName, args.GenerateSourceMapVisualisations, args.LineDirectives)
+	}
+	if args.Cache != "" && !args.GenerateSourceMapVisualisations {
+		c, err := loadCache(args.Cache, args.LineDirectives)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if saveErr := c.save(); saveErr != nil && err == nil {
+				err = saveErr
+			}
+		}()
+		compileWithoutCache := compileFile
+		compileFile = func(fileName string) error {
+			return c.compile(fileName, compileWithoutCache)
+		}
+	}
+	if args.Watch || args.Proxy != "" || args.Command != "" {
+		return watchPath(ctx, out, args, compileFile)
+	}
+	if args.FileName != "" {
+		return processSingleFile(out, args.FileName, compileFile)
+	}
+	return processPath(ctx, out, args.Path, compileFile, args.WorkerCount, opts)
+}
+
+func watchPath(ctx context.Context, out *output.Writer, args Arguments, compile func(fileName string) error) (err error) {
+	path := args.Path
+	if args.FileName != "" {
+		path = args.FileName
+	}
+	w := newWatcher(out, path, args.WorkerCount, compile)
+	w.opts = processor.Options{Exclude: args.Exclude}
+	if args.Clean {
+		w.removed = func(fileName string) {
+			removed, err := removeOrphans(fileName)
+			for _, r := range removed {
+				out.Textf("Removed %s\n", r)
+			}
+			if err != nil {
+				out.FileError(fileName, 0, err)
+			}
+		}
+	}
+	var app *appRunner
+	if args.Command != "" {
+		app = newAppRunner(args.Command)
+		defer app.Stop()
+	}
+	var p *proxy.Handler
+	if args.Proxy != "" {
+		target, err := url.Parse(args.Proxy)
+		if err != nil {
+			return fmt.Errorf("failed to parse proxy URL: %w", err)
+		}
+		p = proxy.New(target)
+		server := &http.Server{
+			Addr:    fmt.Sprintf("127.0.0.1:%d", args.ProxyPort),
+			Handler: p,
+		}
+		listener, err := net.Listen("tcp", server.Addr)
+		if err != nil {
+			return fmt.Errorf("failed to start proxy: %w", err)
+		}
+		go server.Serve(listener)
+		defer server.Close()
+		out.Textf("Proxying from http://%s to %s\n", server.Addr, args.Proxy)
+	}
+	w.afterGenerate = func() {
+		if app != nil {
+			if err := app.Restart(); err != nil {
+				// Write to stderr, so that the error isn't mixed with the JSON or editor output.
+				fmt.Fprintln(os.Stderr, err.Error())
+				return
+			}
+		}
+		if p != nil {
+			if err := waitForApp(ctx, args.Proxy); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return
+			}
+			p.SendReload()
+		}
+	}
+	w.Run(ctx)
+	return nil
+}
+
+func clean(ctx context.Context, out *output.Writer, path string, opts processor.Options) error {
+	removed, err := cleanPath(ctx, path, opts)
+	for _, r := range removed {
+		out.Textf("Removed %s\n", r)
+	}
+	return err
 }
 
-func processSingleFile(fileName string, generateSourceMapVisualisations bool) error {
+func processSingleFile(out *output.Writer, fileName string, compile func(fileName string) error) error {
 	start := time.Now()
-	err := compile(fileName, generateSourceMapVisualisations)
+	err := compile(fileName)
+	if out.Format != output.FormatText {
+		out.File(fileName, time.Since(start), output.StatusOf(err), err)
+	}
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Generated code for %q in %s\n", fileName, time.Since(start))
+	out.Textf("Generated code for %q in %s\n", fileName, time.Since(start))
 	return err
 }
 
-func processPath(path string, generateSourceMapVisualisations bool, workerCount int) (err error) {
+func processPath(ctx context.Context, out *output.Writer, path string, compile func(fileName string) error, workerCount int, opts processor.Options) (err error) {
 	start := time.Now()
 	results := make(chan processor.Result)
-	p := func(fileName string) error {
-		return compile(fileName, generateSourceMapVisualisations)
-	}
-	go processor.Process(path, p, workerCount, results)
+	go processor.Process(ctx, path, compile, workerCount, results, opts)
 	var successCount, errorCount int
 	for r := range results {
 		if r.Error != nil {
+			out.File(r.FileName, r.Duration, output.StatusError, r.Error)
 			err = multierror.Append(err, fmt.Errorf("%s: %w", r.FileName, r.Error))
 			errorCount++
 			continue
 		}
 		successCount++
-		fmt.Printf("%s complete in %v\n", r.FileName, r.Duration)
+		out.File(r.FileName, r.Duration, output.StatusOK, nil)
 	}
-	fmt.Printf("Generated code for %d templates with %d errors in %s\n", successCount+errorCount, errorCount, time.Since(start))
+	out.Textf("Generated code for %d templates with %d errors in %s\n", successCount+errorCount, errorCount, time.Since(start))
 	return err
 }
 
-func compile(fileName string, generateSourceMapVisualisations bool) (err error) {
-	t, err := parser.Parse(fileName)
-	if err != nil {
-		return fmt.Errorf("%s parsing error: %w", fileName, err)
-	}
-	targetFileName := strings.TrimSuffix(fileName, ".templ") + "_templ.go"
-	w, err := os.Create(targetFileName)
-	if err != nil {
-		return fmt.Errorf("%s compilation error: %w", fileName, err)
-	}
-	defer w.Close()
-	b := bufio.NewWriter(w)
-	defer b.Flush()
-	sourceMap, err := generator.Generate(t, b)
+func compile(fileName string, generateSourceMapVisualisations, lineDirectives bool) (err error) {
+	targetFileName, code, sourceMap, err := generate(fileName, lineDirectives)
 	if err != nil {
-		return fmt.Errorf("%s generation error: %w", fileName, err)
+		return err
 	}
-	if b.Flush() != nil {
-		return fmt.Errorf("%s write file error: %w", targetFileName, err)
+	if err = writeIfChanged(targetFileName, code); err != nil {
+		return err
 	}
 	if generateSourceMapVisualisations {
 		err = generateSourceMapVisualisation(fileName, targetFileName, sourceMap)
@@ -95,6 +219,44 @@ func compile(fileName string, generateSourceMapVisualisations bool) (err error)
 	return
 }
 
+// writeIfChanged writes the file atomically, unless it already has the contents, so that the
+// modification time of unchanged files is kept.
+func writeIfChanged(fileName string, contents []byte) error {
+	current, err := os.ReadFile(fileName)
+	if err == nil && bytes.Equal(current, contents) {
+		return nil
+	}
+	if err = atomic.WriteFile(fileName, bytes.NewReader(contents)); err != nil {
+		return fmt.Errorf("%s write file error: %w", fileName, err)
+	}
+	return nil
+}
+
+// goFileName returns the name of the Go file generated from the templ file.
+func goFileName(templFileName string) string {
+	return strings.TrimSuffix(templFileName, ".templ") + "_templ.go"
+}
+
+// generate the Go code for the templ file, without writing it.
+func generate(fileName string, lineDirectives bool) (targetFileName string, code []byte, sourceMap *parser.SourceMap, err error) {
+	t, err := parser.Parse(fileName)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("%s parsing error: %w", fileName, err)
+	}
+	targetFileName = goFileName(fileName)
+	var opts []generator.GenerateOpt
+	if lineDirectives {
+		// The generated file is in the same directory as the templ file.
+		opts = append(opts, generator.WithLineDirectives(filepath.Base(fileName), filepath.Base(targetFileName)))
+	}
+	var b bytes.Buffer
+	sourceMap, err = generator.Generate(t, &b, opts...)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("%s generation error: %w", fileName, err)
+	}
+	return targetFileName, b.Bytes(), sourceMap, nil
+}
+
 func generateSourceMapVisualisation(templFileName, goFileName string, sourceMap *parser.SourceMap) error {
 	var templContents, goContents []byte
 	var templErr, goErr error
@@ -120,15 +282,11 @@ func generateSourceMapVisualisation(templFileName, goFileName string, sourceMap
 	visualisationComponent := visualisation(templFileName, tl, gl)
 
 	targetFileName := strings.TrimSuffix(templFileName, ".templ") + "_templ_sourcemap.html"
-	w, err := os.Create(targetFileName)
-	if err != nil {
+	var b bytes.Buffer
+	if err := visualisationComponent.Render(context.Background(), &b); err != nil {
 		return fmt.Errorf("%s sourcemap visualisation error: %w", templFileName, err)
 	}
-	defer w.Close()
-	b := bufio.NewWriter(w)
-	defer b.Flush()
-
-	return visualisationComponent.Render(context.Background(), b)
+	return writeIfChanged(targetFileName, b.Bytes())
 }
 
 type templLines struct {
@@ -0,0 +1,102 @@
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	scriptPath = "/_templ/reload/script.js"
+	eventsPath = "/_templ/reload/events"
+)
+
+// script reloads the page when the proxy sends a reload event.
+var script = `(function() {
+	var es = new EventSource("` + eventsPath + `");
+	es.onmessage = function(e) {
+		if (e.data === "reload") {
+			window.location.reload();
+		}
+	};
+})();
+`
+
+// scriptTag is a script element that is inserted into HTML responses. It refers to an external
+// script so that it's not blocked by Content-Security-Policy headers that don't allow inline scripts.
+var scriptTag = `<script src="` + scriptPath + `"></script>`
+
+// Handler is a reverse proxy to a web app that adds live reload to its HTML responses.
+type Handler struct {
+	p   *httputil.ReverseProxy
+	sse *sseHandler
+}
+
+// New creates a live reload proxy to the target.
+func New(target *url.URL) *Handler {
+	p := httputil.NewSingleHostReverseProxy(target)
+	director := p.Director
+	p.Director = func(r *http.Request) {
+		director(r)
+		// Let the transport negotiate compression, so that it decompresses responses before the script
+		// is inserted.
+		r.Header.Del("Accept-Encoding")
+	}
+	p.ModifyResponse = insertScriptTag
+	return &Handler{
+		p:   p,
+		sse: newSSEHandler(),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case scriptPath:
+		w.Header().Set("Content-Type", "text/javascript")
+		io.WriteString(w, script)
+	case eventsPath:
+		h.sse.ServeHTTP(w, r)
+	default:
+		h.p.ServeHTTP(w, r)
+	}
+}
+
+// SendReload reloads the pages of all connected browsers.
+func (h *Handler) SendReload() {
+	h.sse.Send("reload")
+}
+
+func insertScriptTag(r *http.Response) error {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "text/html") || r.Header.Get("Content-Encoding") != "" {
+		return nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if err = r.Body.Close(); err != nil {
+		return fmt.Errorf("failed to close response body: %w", err)
+	}
+	body = insertBeforeBodyEnd(body, []byte(scriptTag))
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return nil
+}
+
+// insertBeforeBodyEnd inserts v before the closing body tag, or at the end of the document if there isn't one.
+func insertBeforeBodyEnd(body, v []byte) []byte {
+	index := bytes.LastIndex(bytes.ToLower(body), []byte("</body>"))
+	if index < 0 {
+		return append(body, v...)
+	}
+	output := make([]byte, 0, len(body)+len(v))
+	output = append(output, body[:index]...)
+	output = append(output, v...)
+	return append(output, body[index:]...)
+}
@@ -0,0 +1,138 @@
+package proxy
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProxy(t *testing.T) {
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			io.WriteString(w, `<html><body><h1>Hello</h1></body></html>`)
+		case "/fragment":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			io.WriteString(w, `<h1>Hello</h1>`)
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"body":"</body>"}`)
+		case "/gzip":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+				io.WriteString(w, `<h1>Hello</h1>`)
+				return
+			}
+			w.Header().Set("Content-Encoding", "gzip")
+			gw := gzip.NewWriter(w)
+			defer gw.Close()
+			io.WriteString(gw, `<h1>Hello</h1>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer app.Close()
+	u, err := url.Parse(app.URL)
+	if err != nil {
+		t.Fatalf("failed to parse URL: %v", err)
+	}
+	p := New(u)
+	proxy := httptest.NewServer(p)
+	defer proxy.Close()
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			path:           "/html",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `<html><body><h1>Hello</h1>` + scriptTag + `</body></html>`,
+		},
+		{
+			path:           "/fragment",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `<h1>Hello</h1>` + scriptTag,
+		},
+		{
+			path:           "/json",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"body":"</body>"}`,
+		},
+		{
+			path:           "/gzip",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `<h1>Hello</h1>` + scriptTag,
+		},
+		{
+			path:           "/missing",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "404 page not found\n",
+		},
+		{
+			path:           scriptPath,
+			expectedStatus: http.StatusOK,
+			expectedBody:   script,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := http.Get(proxy.URL + tt.path)
+			if err != nil {
+				t.Fatalf("failed to get: %v", err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("failed to read body: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if diff := cmp.Diff(tt.expectedBody, string(body)); diff != "" {
+				t.Error(diff)
+			}
+			if cl := resp.Header.Get("Content-Length"); cl != "" && cl != strconv.Itoa(len(body)) {
+				t.Errorf("expected Content-Length %d, got %s", len(body), cl)
+			}
+		})
+	}
+
+	t.Run("connected browsers receive reload events", func(t *testing.T) {
+		resp, err := http.Get(proxy.URL + eventsPath)
+		if err != nil {
+			t.Fatalf("failed to get events: %v", err)
+		}
+		defer resp.Body.Close()
+		if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+			t.Errorf("expected event stream, got %q", ct)
+		}
+		p.SendReload()
+		lines := make(chan string)
+		go func() {
+			r := bufio.NewReader(resp.Body)
+			line, _ := r.ReadString('\n')
+			lines <- line
+		}()
+		select {
+		case line := <-lines:
+			if diff := cmp.Diff("data: reload\n", line); diff != "" {
+				t.Error(diff)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("timed out waiting for reload event")
+		}
+	})
+}
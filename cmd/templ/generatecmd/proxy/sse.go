@@ -0,0 +1,75 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// sseHandler sends server-sent events to all connected clients.
+type sseHandler struct {
+	m        sync.Mutex
+	counter  int64
+	requests map[int64]chan string
+}
+
+func newSSEHandler() *sseHandler {
+	return &sseHandler{
+		requests: make(map[int64]chan string),
+	}
+}
+
+// Send data to all connected clients. Clients that haven't received the previous event yet are skipped.
+func (s *sseHandler) Send(data string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, events := range s.requests {
+		select {
+		case events <- data:
+		default:
+		}
+	}
+}
+
+func (s *sseHandler) add() (id int64, events chan string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.counter++
+	events = make(chan string, 1)
+	s.requests[s.counter] = events
+	return s.counter, events
+}
+
+func (s *sseHandler) remove(id int64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	delete(s.requests, id)
+}
+
+func (s *sseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	// Register the client before the response starts, so that events sent after the client
+	// has connected are received.
+	id, events := s.add()
+	defer s.remove(id)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-events:
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
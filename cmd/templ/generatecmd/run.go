@@ -0,0 +1,99 @@
+package generatecmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+)
+
+const (
+	appStopTimeout  = 5 * time.Second
+	appReadyTimeout = 10 * time.Second
+)
+
+// appRunner runs the command that starts the app, so that it can be restarted after code is generated.
+type appRunner struct {
+	command string
+	cmd     *exec.Cmd
+	exited  chan struct{}
+}
+
+func newAppRunner(command string) *appRunner {
+	return &appRunner{
+		command: command,
+	}
+}
+
+// Restart stops the app if it's running, and starts it again.
+func (a *appRunner) Restart() error {
+	if err := a.Stop(); err != nil {
+		return err
+	}
+	cmd := newShellCommand(a.command)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to run %q: %w", a.command, err)
+	}
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		cmd.Wait()
+	}()
+	a.cmd, a.exited = cmd, exited
+	return nil
+}
+
+// Stop the app, including any processes it started, e.g. the binary built by `go run`.
+func (a *appRunner) Stop() error {
+	if a.cmd == nil {
+		return nil
+	}
+	defer func() {
+		a.cmd, a.exited = nil, nil
+	}()
+	select {
+	case <-a.exited:
+		return nil
+	default:
+	}
+	if err := stopProcessGroup(a.cmd); err != nil {
+		return fmt.Errorf("failed to stop %q: %w", a.command, err)
+	}
+	select {
+	case <-a.exited:
+		return nil
+	case <-time.After(appStopTimeout):
+	}
+	if err := killProcessGroup(a.cmd); err != nil {
+		return fmt.Errorf("failed to kill %q: %w", a.command, err)
+	}
+	<-a.exited
+	return nil
+}
+
+// waitForApp waits until the app at the URL responds to requests, so that browsers aren't reloaded
+// while it's still starting up.
+func waitForApp(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, appReadyTimeout)
+	defer cancel()
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %q: %w", url, err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
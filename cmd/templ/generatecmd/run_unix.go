@@ -0,0 +1,33 @@
+//go:build !windows
+// +build !windows
+
+package generatecmd
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+func newShellCommand(command string) *exec.Cmd {
+	cmd := exec.Command("sh", "-c", command)
+	// Start a process group, so that the processes started by the command are stopped with it.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return cmd
+}
+
+func stopProcessGroup(cmd *exec.Cmd) error {
+	return signalProcessGroup(cmd, syscall.SIGTERM)
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return signalProcessGroup(cmd, syscall.SIGKILL)
+}
+
+func signalProcessGroup(cmd *exec.Cmd, sig syscall.Signal) error {
+	err := syscall.Kill(-cmd.Process.Pid, sig)
+	if err == syscall.ESRCH {
+		// The processes have already exited.
+		return nil
+	}
+	return err
+}
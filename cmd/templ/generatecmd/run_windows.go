@@ -0,0 +1,22 @@
+//go:build windows
+// +build windows
+
+package generatecmd
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+func newShellCommand(command string) *exec.Cmd {
+	return exec.Command("cmd", "/C", command)
+}
+
+func stopProcessGroup(cmd *exec.Cmd) error {
+	// Windows processes can't be asked to stop, so kill the process tree.
+	return killProcessGroup(cmd)
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+}
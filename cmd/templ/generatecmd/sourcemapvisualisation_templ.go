@@ -45,18 +45,7 @@ func visualisation(templFileName string, left, right templ.Component) templ.Comp
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
-		_, err = io.WriteString(w, "<html>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<head>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<title>")
+		_, err = io.WriteString(w, `<html><head><title>`)
 		if err != nil {
 			return err
 		}
@@ -65,59 +54,10 @@ func visualisation(templFileName string, left, right templ.Component) templ.Comp
 		if err != nil {
 			return err
 		}
-		// Whitespace (normalised)
-		_, err = io.WriteString(w, ` `)
-		if err != nil {
-			return err
-		}
-		// Text
-		var_2 := `- Source Map Visualisation`
-		_, err = io.WriteString(w, var_2)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</title>")
-		if err != nil {
-			return err
-		}
-// RawElement
-		_, err = io.WriteString(w, "<style")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " type=\"text/css\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-// Text
-var_3 := `
+		_, err = io.WriteString(w, ` - Source Map Visualisation</title><style type="text/css">
 				.mapped { background-color: green }
 				.highlighted { background-color: yellow }
-			`
-_, err = io.WriteString(w, var_3)
-if err != nil {
-	return err
-}
-		_, err = io.WriteString(w, "</style>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</head>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<body>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<h1>")
+			</style></head><body><h1>`)
 		if err != nil {
 			return err
 		}
@@ -126,71 +66,43 @@ if err != nil {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</h1>")
+		_, err = io.WriteString(w, `</h1>`)
 		if err != nil {
 			return err
 		}
-		// Element (standard)
 		// Element CSS
-		var var_4 templ.CSSClasses = templ.Classes(row())
-		err = templ.RenderCSS(ctx, w, var_4)
+		var var_2 templ.CSSClasses = templ.Classes(row())
+		err = templ.RenderCSS(ctx, w, var_2)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "<div")
+		_, err = io.WriteString(w, `<div class="`)
 		if err != nil {
 			return err
 		}
-		// Element Attributes
-		_, err = io.WriteString(w, " class=")
+		_, err = io.WriteString(w, templ.EscapeString(var_2.String()))
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, `">`)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, templ.EscapeString(var_4.String()))
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
 		// Element CSS
-		var var_5 templ.CSSClasses = templ.Classes(column(), code())
-		err = templ.RenderCSS(ctx, w, var_5)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "<div")
+		var var_3 templ.CSSClasses = templ.Classes(column(), code())
+		err = templ.RenderCSS(ctx, w, var_3)
 		if err != nil {
 			return err
 		}
-		// Element Attributes
-		_, err = io.WriteString(w, " class=")
+		_, err = io.WriteString(w, `<div class="`)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, templ.EscapeString(var_3.String()))
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, templ.EscapeString(var_5.String()))
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `">`)
 		if err != nil {
 			return err
 		}
@@ -199,39 +111,25 @@ if err != nil {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</div>")
+		_, err = io.WriteString(w, `</div>`)
 		if err != nil {
 			return err
 		}
-		// Element (standard)
 		// Element CSS
-		var var_6 templ.CSSClasses = templ.Classes(column(), code())
-		err = templ.RenderCSS(ctx, w, var_6)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "<div")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " class=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
+		var var_4 templ.CSSClasses = templ.Classes(column(), code())
+		err = templ.RenderCSS(ctx, w, var_4)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, templ.EscapeString(var_6.String()))
+		_, err = io.WriteString(w, `<div class="`)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, templ.EscapeString(var_4.String()))
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `">`)
 		if err != nil {
 			return err
 		}
@@ -240,19 +138,7 @@ if err != nil {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</div>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</div>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</body>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</html>")
+		_, err = io.WriteString(w, `</div></div></body></html>`)
 		if err != nil {
 			return err
 		}
@@ -294,12 +180,11 @@ func mappedCharacter(s string, sourceID, targetID string) templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
-		var_7 := ctx
-		ctx = templ.ClearChildren(var_7)
-		// Element (standard)
+		var_5 := ctx
+		ctx = templ.ClearChildren(var_5)
 		// Element CSS
-		var var_8 templ.CSSClasses = templ.Classes(templ.Class("mapped"), templ.Class(sourceID), templ.Class(targetID))
-		err = templ.RenderCSS(ctx, w, var_8)
+		var var_6 templ.CSSClasses = templ.Classes(templ.Class("mapped"), templ.Class(sourceID), templ.Class(targetID))
+		err = templ.RenderCSS(ctx, w, var_6)
 		if err != nil {
 			return err
 		}
@@ -308,62 +193,33 @@ func mappedCharacter(s string, sourceID, targetID string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "<span")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " class=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, templ.EscapeString(var_8.String()))
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " onMouseOver=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		var var_9 templ.ComponentScript = highlight(sourceID, targetID)
-		_, err = io.WriteString(w, var_9.Call)
+		_, err = io.WriteString(w, `<span class="`)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, templ.EscapeString(var_6.String()))
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, " onMouseOut=")
+		_, err = io.WriteString(w, `" onMouseOver="`)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
+		var var_7 templ.ComponentScript = highlight(sourceID, targetID)
+		_, err = io.WriteString(w, var_7.Call)
 		if err != nil {
 			return err
 		}
-		var var_10 templ.ComponentScript = removeHighlight(sourceID, targetID)
-		_, err = io.WriteString(w, var_10.Call)
+		_, err = io.WriteString(w, `" onMouseOut="`)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
+		var var_8 templ.ComponentScript = removeHighlight(sourceID, targetID)
+		_, err = io.WriteString(w, var_8.Call)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `">`)
 		if err != nil {
 			return err
 		}
@@ -372,7 +228,7 @@ func mappedCharacter(s string, sourceID, targetID string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</span>")
+		_, err = io.WriteString(w, `</span>`)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,103 @@
+package generatecmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/a-h/templ/cmd/templ/output"
+	"github.com/a-h/templ/cmd/templ/processor"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ErrOutOfDate is returned in verify mode if any of the generated files are stale or missing.
+var ErrOutOfDate = errors.New("generated code is out of date, run templ generate")
+
+const codeGeneratedCommentPrefix = "// Code generated by templ@"
+
+// verifyPath checks that the generated code for each templ file in the path, or the single file if
+// fileName is set, is up to date. The list of stale or missing files is written to out. No files are
+// written.
+func verifyPath(ctx context.Context, out *output.Writer, path, fileName string, lineDirectives bool, workerCount int, opts processor.Options) (err error) {
+	var m sync.Mutex
+	var outOfDate []string
+	check := func(fileName string) error {
+		start := time.Now()
+		targetFileName, status, err := verify(fileName, lineDirectives)
+		if err != nil {
+			return err
+		}
+		if out.Format != output.FormatText {
+			out.File(targetFileName, time.Since(start), status, nil)
+		}
+		if status == output.StatusOK {
+			return nil
+		}
+		m.Lock()
+		defer m.Unlock()
+		outOfDate = append(outOfDate, fmt.Sprintf("%s: %s", status, targetFileName))
+		return nil
+	}
+	if fileName != "" {
+		start := time.Now()
+		if err = check(fileName); err != nil {
+			out.File(fileName, time.Since(start), output.StatusError, err)
+		}
+	} else {
+		results := make(chan processor.Result)
+		go processor.Process(ctx, path, check, workerCount, results, opts)
+		for r := range results {
+			if r.Error != nil {
+				out.File(r.FileName, r.Duration, output.StatusError, r.Error)
+				err = multierror.Append(err, fmt.Errorf("%s: %w", r.FileName, r.Error))
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	sort.Strings(outOfDate)
+	for _, s := range outOfDate {
+		out.Textf("%s\n", s)
+	}
+	if len(outOfDate) > 0 {
+		return fmt.Errorf("%w: %d generated files are stale or missing", ErrOutOfDate, len(outOfDate))
+	}
+	return nil
+}
+
+// verify generates the code for the templ file in memory, and compares it to the file on disk. The
+// status is StatusMissing or StatusStale if the file on disk isn't up to date.
+func verify(fileName string, lineDirectives bool) (targetFileName string, status output.Status, err error) {
+	targetFileName, expected, _, err := generate(fileName, lineDirectives)
+	if err != nil {
+		return targetFileName, output.StatusError, err
+	}
+	actual, err := os.ReadFile(targetFileName)
+	if os.IsNotExist(err) {
+		return targetFileName, output.StatusMissing, nil
+	}
+	if err != nil {
+		return targetFileName, output.StatusError, fmt.Errorf("%s read file error: %w", targetFileName, err)
+	}
+	// Code generated by other versions of templ is up to date if the only difference is the version.
+	if !bytes.Equal(withoutCodeGeneratedComment(expected), withoutCodeGeneratedComment(actual)) {
+		return targetFileName, output.StatusStale, nil
+	}
+	return targetFileName, output.StatusOK, nil
+}
+
+func withoutCodeGeneratedComment(code []byte) []byte {
+	if !bytes.HasPrefix(code, []byte(codeGeneratedCommentPrefix)) {
+		return code
+	}
+	if i := bytes.IndexByte(code, '\n'); i >= 0 {
+		return code[i+1:]
+	}
+	return nil
+}
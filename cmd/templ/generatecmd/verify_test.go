@@ -0,0 +1,111 @@
+package generatecmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ/cmd/templ/output"
+	"github.com/a-h/templ/cmd/templ/processor"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	templ := func(name, contents string) string {
+		t.Helper()
+		fileName := filepath.Join(dir, name+".templ")
+		if err := os.WriteFile(fileName, []byte("package test\n\ntempl "+name+"() {\n\t<div>"+contents+"</div>\n}\n"), 0644); err != nil {
+			t.Fatalf("failed to write template: %v", err)
+		}
+		return fileName
+	}
+	expectOutOfDate := func(t *testing.T, expected ...string) {
+		t.Helper()
+		var w strings.Builder
+		err := verifyPath(context.Background(), output.NewWriter(&w, output.FormatText), dir, "", false, 2, processor.Options{})
+		if len(expected) == 0 && err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(expected) > 0 && !errors.Is(err, ErrOutOfDate) {
+			t.Errorf("expected ErrOutOfDate, got %v", err)
+		}
+		var actual []string
+		for _, line := range strings.Split(strings.TrimSpace(w.String()), "\n") {
+			if line != "" {
+				actual = append(actual, strings.Replace(line, dir+string(filepath.Separator), "", 1))
+			}
+		}
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Error(diff)
+		}
+	}
+
+	a := templ("a", "A")
+	b := templ("b", "B")
+	t.Run("missing files are listed, and not created", func(t *testing.T) {
+		expectOutOfDate(t, "missing: a_templ.go", "missing: b_templ.go")
+		if _, err := os.Stat(filepath.Join(dir, "a_templ.go")); !os.IsNotExist(err) {
+			t.Errorf("expected the generated file not to be created, got %v", err)
+		}
+	})
+	t.Run("generated files are up to date", func(t *testing.T) {
+		for _, fileName := range []string{a, b} {
+			if err := compile(fileName, false, false); err != nil {
+				t.Fatalf("failed to generate code: %v", err)
+			}
+		}
+		expectOutOfDate(t)
+	})
+	t.Run("files generated by other versions of templ are up to date", func(t *testing.T) {
+		fileName := filepath.Join(dir, "a_templ.go")
+		code, err := os.ReadFile(fileName)
+		if err != nil {
+			t.Fatalf("failed to read generated code: %v", err)
+		}
+		code = []byte(codeGeneratedCommentPrefix + "v0.0.1 DO NOT EDIT." + string(code[strings.Index(string(code), "\n"):]))
+		if err = os.WriteFile(fileName, code, 0644); err != nil {
+			t.Fatalf("failed to write generated code: %v", err)
+		}
+		expectOutOfDate(t)
+	})
+	t.Run("changed templates are listed as stale, and not regenerated", func(t *testing.T) {
+		templ("b", "Changed")
+		expectOutOfDate(t, "stale: b_templ.go")
+		expectOutOfDate(t, "stale: b_templ.go")
+	})
+	t.Run("single files can be verified", func(t *testing.T) {
+		out := output.NewWriter(io.Discard, output.FormatText)
+		if err := verifyPath(context.Background(), out, "", a, false, 1, processor.Options{}); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if err := verifyPath(context.Background(), out, "", b, false, 1, processor.Options{}); !errors.Is(err, ErrOutOfDate) {
+			t.Errorf("expected ErrOutOfDate, got %v", err)
+		}
+	})
+	t.Run("missing directories are an error", func(t *testing.T) {
+		out := output.NewWriter(io.Discard, output.FormatText)
+		err := verifyPath(context.Background(), out, filepath.Join(dir, "missing"), "", false, 1, processor.Options{})
+		if err == nil || errors.Is(err, ErrOutOfDate) {
+			t.Errorf("expected an error, got %v", err)
+		}
+	})
+	t.Run("stale files are written as JSON", func(t *testing.T) {
+		var w strings.Builder
+		if err := verifyPath(context.Background(), output.NewWriter(&w, output.FormatJSON), "", b, false, 1, processor.Options{}); !errors.Is(err, ErrOutOfDate) {
+			t.Errorf("expected ErrOutOfDate, got %v", err)
+		}
+		var e output.Event
+		if err := json.Unmarshal([]byte(w.String()), &e); err != nil {
+			t.Fatalf("failed to unmarshal %q: %v", w.String(), err)
+		}
+		if e.Path != filepath.Join(dir, "b_templ.go") || e.Status != output.StatusStale {
+			t.Errorf("expected b_templ.go to be stale, got %+v", e)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package generatecmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/a-h/templ/cmd/templ/output"
+	"github.com/a-h/templ/cmd/templ/processor"
+)
+
+const (
+	defaultWatchInterval = 250 * time.Millisecond
+	defaultWatchDebounce = 100 * time.Millisecond
+)
+
+// errUnchanged is returned by watcher.process when a file doesn't need to be generated.
+var errUnchanged = errors.New("unchanged")
+
+type fileState struct {
+	// modTime of the file when it was last seen.
+	modTime time.Time
+	// seenAt is when modTime was first seen.
+	seenAt time.Time
+	// generatedModTime is the modTime of the file when code was last generated.
+	generatedModTime time.Time
+}
+
+// watcher regenerates the templ files in a path that have changed since they were last generated.
+type watcher struct {
+	out         *output.Writer
+	path        string
+	opts        processor.Options
+	workerCount int
+	// interval between checks for changes.
+	interval time.Duration
+	// debounce is how long a file must be unchanged before code is generated, so that a burst of saves
+	// only causes code to be generated once.
+	debounce time.Duration
+	// generate code for the file.
+	generate func(fileName string) error
+	// now returns the current time.
+	now func() time.Time
+	// afterGenerate is called after the initial run, and after code is generated for changed files.
+	afterGenerate func()
+	// removed is called with each templ file that has been removed since the last check.
+	removed func(fileName string)
+
+	m     sync.Mutex
+	files map[string]*fileState
+}
+
+func newWatcher(out *output.Writer, path string, workerCount int, generate func(fileName string) error) *watcher {
+	return &watcher{
+		out:         out,
+		path:        path,
+		workerCount: workerCount,
+		interval:    defaultWatchInterval,
+		debounce:    defaultWatchDebounce,
+		generate:    generate,
+		now:         time.Now,
+		files:       make(map[string]*fileState),
+	}
+}
+
+// Run generates code for every file, then watches for changes until the context is cancelled.
+func (w *watcher) Run(ctx context.Context) {
+	start := time.Now()
+	successCount, errorCount := w.processPath(ctx, true)
+	w.out.Textf("Generated code for %d templates with %d errors in %s\n", successCount, errorCount, time.Since(start))
+	if w.afterGenerate != nil {
+		w.afterGenerate()
+	}
+	w.out.Textf("Watching %q for changes...\n", w.path)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if successCount, _ := w.processPath(ctx, false); successCount > 0 && w.afterGenerate != nil {
+				w.afterGenerate()
+			}
+		}
+	}
+}
+
+// processPath generates code for the files that have changed, reporting errors without stopping.
+func (w *watcher) processPath(ctx context.Context, initial bool) (successCount, errorCount int) {
+	results := make(chan processor.Result)
+	p := func(fileName string) error {
+		return w.process(fileName, initial)
+	}
+	go processor.Process(ctx, w.path, p, w.workerCount, results, w.opts)
+	seen := make(map[string]struct{})
+	var walkFailed bool
+	for r := range results {
+		seen[r.FileName] = struct{}{}
+		if errors.Is(r.Error, errUnchanged) {
+			continue
+		}
+		if r.Error != nil {
+			// Errors are reported as they happen, since watching doesn't stop.
+			w.out.FileError(r.FileName, r.Duration, r.Error)
+			errorCount++
+			walkFailed = walkFailed || r.Walk
+			continue
+		}
+		successCount++
+		w.out.File(r.FileName, r.Duration, output.StatusOK, nil)
+	}
+	if walkFailed {
+		// Files that weren't seen may still exist.
+		return
+	}
+	for _, fileName := range w.forget(seen) {
+		if w.removed != nil {
+			w.removed(fileName)
+		}
+	}
+	return
+}
+
+// forget the files that weren't seen, and return their names.
+func (w *watcher) forget(seen map[string]struct{}) (removed []string) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	for fileName := range w.files {
+		if _, ok := seen[fileName]; !ok {
+			delete(w.files, fileName)
+			removed = append(removed, fileName)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// process generates code for the file if it has changed since code was last generated, and it's not
+// been changed again within the debounce period. On the initial run, code is generated for every file.
+func (w *watcher) process(fileName string, initial bool) error {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return err
+	}
+	if !w.shouldGenerate(fileName, info.ModTime(), initial) {
+		return errUnchanged
+	}
+	return w.generate(fileName)
+}
+
+func (w *watcher) shouldGenerate(fileName string, modTime time.Time, initial bool) bool {
+	w.m.Lock()
+	defer w.m.Unlock()
+	now := w.now()
+	state, ok := w.files[fileName]
+	if !ok {
+		state = &fileState{modTime: modTime, seenAt: now}
+		w.files[fileName] = state
+	}
+	if initial {
+		state.generatedModTime = modTime
+		return true
+	}
+	if state.generatedModTime.Equal(modTime) {
+		return false
+	}
+	if !state.modTime.Equal(modTime) {
+		// The file has changed again, so wait until it stops changing.
+		state.modTime = modTime
+		state.seenAt = now
+	}
+	if now.Sub(state.seenAt) < w.debounce {
+		return false
+	}
+	// Parse errors are reported once, and aren't retried until the file changes.
+	state.generatedModTime = modTime
+	return true
+}
@@ -0,0 +1,101 @@
+package generatecmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/a-h/templ/cmd/templ/output"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.templ")
+	b := filepath.Join(dir, "b.templ")
+	writeFile(t, a, time.Unix(1, 0))
+	writeFile(t, b, time.Unix(1, 0))
+
+	var m sync.Mutex
+	var generated []string
+	now := time.Unix(100, 0)
+	w := newWatcher(output.NewWriter(io.Discard, output.FormatText), dir, 2, func(fileName string) error {
+		m.Lock()
+		defer m.Unlock()
+		generated = append(generated, filepath.Base(fileName))
+		return nil
+	})
+	w.now = func() time.Time { return now }
+	expectGenerated := func(t *testing.T, initial bool, expected ...string) {
+		t.Helper()
+		generated = nil
+		w.processPath(context.Background(), initial)
+		sort.Strings(generated)
+		if diff := cmp.Diff(expected, generated); diff != "" {
+			t.Error(diff)
+		}
+	}
+
+	t.Run("every file is generated initially", func(t *testing.T) {
+		expectGenerated(t, true, "a.templ", "b.templ")
+	})
+	t.Run("unchanged files are not generated", func(t *testing.T) {
+		now = now.Add(time.Second)
+		expectGenerated(t, false)
+	})
+	t.Run("changed files are generated after the debounce period", func(t *testing.T) {
+		writeFile(t, a, time.Unix(2, 0))
+		expectGenerated(t, false)
+		now = now.Add(w.debounce)
+		expectGenerated(t, false, "a.templ")
+		now = now.Add(w.debounce)
+		expectGenerated(t, false)
+	})
+	t.Run("a burst of changes is only generated once", func(t *testing.T) {
+		writeFile(t, b, time.Unix(3, 0))
+		expectGenerated(t, false)
+		now = now.Add(w.debounce / 2)
+		writeFile(t, b, time.Unix(4, 0))
+		expectGenerated(t, false)
+		now = now.Add(w.debounce / 2)
+		expectGenerated(t, false)
+		now = now.Add(w.debounce / 2)
+		expectGenerated(t, false, "b.templ")
+	})
+	t.Run("new files are generated", func(t *testing.T) {
+		c := filepath.Join(dir, "c.templ")
+		writeFile(t, c, time.Unix(5, 0))
+		expectGenerated(t, false)
+		now = now.Add(w.debounce)
+		expectGenerated(t, false, "c.templ")
+	})
+	t.Run("removed files are reported", func(t *testing.T) {
+		var removed []string
+		w.removed = func(fileName string) {
+			removed = append(removed, filepath.Base(fileName))
+		}
+		if err := os.Remove(a); err != nil {
+			t.Fatalf("failed to remove file: %v", err)
+		}
+		expectGenerated(t, false)
+		expectGenerated(t, false)
+		if diff := cmp.Diff([]string{"a.templ"}, removed); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func writeFile(t *testing.T, fileName string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(fileName, []byte("package test\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.Chtimes(fileName, modTime, modTime); err != nil {
+		t.Fatalf("failed to set file time: %v", err)
+	}
+}
@@ -1,35 +1,23 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
-	"runtime/debug"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/a-h/templ"
+	"github.com/a-h/templ/cmd/templ/config"
 	"github.com/a-h/templ/cmd/templ/fmtcmd"
 	"github.com/a-h/templ/cmd/templ/generatecmd"
 	"github.com/a-h/templ/cmd/templ/lspcmd"
 	"github.com/a-h/templ/cmd/templ/migratecmd"
+	"github.com/a-h/templ/cmd/templ/output"
 )
 
-// Source builds use this value. When installed using `go install github.com/a-h/templ/cmd/templ@latest` the `version` variable is empty, but
-// the debug.ReadBuildInfo return value provides the package version number installed by `go install`
-func goInstallVersion() string {
-	info, ok := debug.ReadBuildInfo()
-	if !ok {
-		return "unknown"
-	}
-	return info.Main.Version
-}
-
-func getVersion() string {
-	if templ.Version != "" {
-		return templ.Version
-	}
-	return goInstallVersion()
-}
-
 func main() {
 	if len(os.Args) < 2 {
 		usage()
@@ -49,15 +37,66 @@ func main() {
 		lspCmd(os.Args[2:])
 		return
 	case "version":
-		fmt.Println(getVersion())
+		fmt.Println(templ.GetVersion())
 		return
 	case "--version":
-		fmt.Println(getVersion())
+		fmt.Println(templ.GetVersion())
 		return
 	}
 	usage()
 }
 
+// applyConfig sets the flags that weren't passed on the command line to the values in the project's
+// templ.toml or templ.json file, if there is one.
+func applyConfig(cmd *flag.FlagSet) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	c, err := config.Find(wd)
+	if err != nil {
+		return err
+	}
+	set := make(map[string]bool)
+	cmd.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	for name, values := range c.Flags(cmd.Name()) {
+		if set[name] {
+			continue
+		}
+		for _, value := range values {
+			if err = cmd.Set(name, value); err != nil {
+				return fmt.Errorf("%s: invalid %s setting: %w", c.FileName, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// signalContext returns a context that's cancelled on Ctrl-C, so that commands stop processing files
+// and exit cleanly. Only the first signal is caught, so that a second Ctrl-C exits immediately.
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// stringsFlag is a flag that can be set more than once.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
 func usage() {
 	fmt.Println(`usage: templ <command> [parameters]
 To see help text, you can run:
@@ -76,21 +115,53 @@ func generateCmd(args []string) {
 	fileName := cmd.String("f", "", "Optionally generates code for a single file, e.g. -f header.templ")
 	path := cmd.String("path", ".", "Generates code for all files in path.")
 	sourceMapVisualisations := cmd.Bool("sourceMapVisualisations", false, "Set to trye to generate HTML files to visualise the templ code and its corresponding Go code.")
+	lineDirectives := cmd.Bool("lineDirectives", false, "Set to true to add line directives to the generated code, so that Go compiler errors and stack traces refer to the templ files.")
+	watch := cmd.Bool("watch", false, "Set to true to watch the path for changes and regenerate code.")
+	proxy := cmd.String("proxy", "", "Set the URL of an app, e.g. http://localhost:8080, to proxy it and reload browsers when code is generated. Implies -watch.")
+	proxyPort := cmd.Int("proxyPort", generatecmd.DefaultProxyPort, "The port the proxy listens on.")
+	command := cmd.String("cmd", "", "Set the command that starts the app, e.g. \"go run .\", to restart it when code is generated. Implies -watch.")
+	verify := cmd.Bool("verify", false, "Set to true to check that the generated code is up to date without writing any files. Stale or missing files are listed, and the exit status is non-zero if there are any.")
+	clean := cmd.Bool("clean", false, "Set to true to remove generated files that don't have a templ file, e.g. because it was renamed or deleted. In watch mode, generated files are also removed when templ files are removed.")
+	cache := cmd.String("cache", "", "Set the path of a file used to record the hashes of templ files and generated code, so that unchanged files are skipped without being parsed, e.g. -cache .templ-cache.json. Not used with -sourceMapVisualisations.")
 	workerCount := cmd.Int("w", 4, "Number of workers to run in parallel.")
+	format := cmd.String("format", "text", "The output format: text, json for a JSON object per file on each line, or editor for errors as file:line:col: message.")
+	var exclude stringsFlag
+	cmd.Var(&exclude, "exclude", "A glob pattern of files and directories to skip, e.g. -exclude \"*_test.templ\". Can be repeated. node_modules, vendor and .git directories, and the patterns in a .templignore file, are always skipped.")
 	helpFlag := cmd.Bool("help", false, "Print help and exit.")
 	err := cmd.Parse(args)
 	if err != nil || *helpFlag {
 		cmd.PrintDefaults()
 		return
 	}
-	err = generatecmd.Run(generatecmd.Arguments{
+	if err = applyConfig(cmd); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	outputFormat, err := output.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+	err = generatecmd.Run(ctx, generatecmd.Arguments{
 		FileName:                        *fileName,
 		Path:                            *path,
 		WorkerCount:                     *workerCount,
 		GenerateSourceMapVisualisations: *sourceMapVisualisations,
+		LineDirectives:                  *lineDirectives,
+		Watch:                           *watch,
+		Proxy:                           *proxy,
+		ProxyPort:                       *proxyPort,
+		Command:                         *command,
+		Verify:                          *verify,
+		Clean:                           *clean,
+		Cache:                           *cache,
+		Format:                          outputFormat,
+		Exclude:                         exclude,
 	})
 	if err != nil {
-		fmt.Println(err.Error())
+		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
 }
@@ -99,33 +170,88 @@ func migrateCmd(args []string) {
 	cmd := flag.NewFlagSet("migrate", flag.ExitOnError)
 	fileName := cmd.String("f", "", "Optionally migrate a single file, e.g. -f header.templ")
 	path := cmd.String("path", ".", "Migrates code for all files in path.")
+	workerCount := cmd.Int("w", 4, "Number of workers to run in parallel.")
+	format := cmd.String("format", "text", "The output format: text, json for a JSON object per file on each line, or editor for errors as file:line:col: message.")
+	var exclude stringsFlag
+	cmd.Var(&exclude, "exclude", "A glob pattern of files and directories to skip, e.g. -exclude \"*_test.templ\". Can be repeated. node_modules, vendor and .git directories, and the patterns in a .templignore file, are always skipped.")
 	helpFlag := cmd.Bool("help", false, "Print help and exit.")
 	err := cmd.Parse(args)
 	if err != nil || *helpFlag {
 		cmd.PrintDefaults()
 		return
 	}
-	err = migratecmd.Run(migratecmd.Arguments{
-		FileName: *fileName,
-		Path:     *path,
+	if err = applyConfig(cmd); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	outputFormat, err := output.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+	err = migratecmd.Run(ctx, migratecmd.Arguments{
+		FileName:    *fileName,
+		Path:        *path,
+		Format:      outputFormat,
+		Exclude:     exclude,
+		WorkerCount: *workerCount,
 	})
 	if err != nil {
-		fmt.Println(err.Error())
+		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
 }
 
 func fmtCmd(args []string) {
 	cmd := flag.NewFlagSet("fmt", flag.ExitOnError)
+	cmd.Usage = func() {
+		fmt.Fprintln(cmd.Output(), `usage: templ fmt [flags] [paths...]
+Formats the templ files in the paths, which can be files or directories. If no paths are given, stdin is formatted to stdout.`)
+		cmd.PrintDefaults()
+	}
+	check := cmd.Bool("check", false, "Set to true to list the files that aren't formatted, and exit with a non-zero status if there are any.")
+	diff := cmd.Bool("diff", false, "Set to true to print a unified diff of the changes that formatting would make.")
+	write := cmd.Bool("w", false, "Set to true to write the formatted files in place. This is the default, unless -check, -diff or -stdout are set.")
+	stdout := cmd.Bool("stdout", false, "Set to true to print the formatted files to stdout.")
+	workerCount := cmd.Int("workerCount", 4, "Number of workers to run in parallel.")
+	format := cmd.String("format", "text", "The output format: text, json for a JSON object per file on each line, or editor for errors as file:line:col: message. Can't be combined with -diff or -stdout.")
+	var exclude stringsFlag
+	cmd.Var(&exclude, "exclude", "A glob pattern of files and directories to skip, e.g. -exclude \"*_test.templ\". Can be repeated. node_modules, vendor and .git directories, and the patterns in a .templignore file, are always skipped.")
 	helpFlag := cmd.Bool("help", false, "Print help and exit.")
 	err := cmd.Parse(args)
 	if err != nil || *helpFlag {
-		cmd.PrintDefaults()
+		cmd.Usage()
 		return
 	}
-	err = fmtcmd.Run(args)
+	if err = applyConfig(cmd); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	outputFormat, err := output.ParseFormat(*format)
 	if err != nil {
-		fmt.Println(err.Error())
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	// Reading stdin can't be cancelled, so Ctrl-C is only caught when formatting files.
+	ctx, cancel := context.WithCancel(context.Background())
+	if cmd.NArg() > 0 {
+		ctx, cancel = signalContext()
+	}
+	defer cancel()
+	err = fmtcmd.Run(ctx, os.Stdin, os.Stdout, fmtcmd.Arguments{
+		Paths:       cmd.Args(),
+		Check:       *check,
+		Diff:        *diff,
+		Write:       *write,
+		Stdout:      *stdout,
+		Format:      outputFormat,
+		Exclude:     exclude,
+		WorkerCount: *workerCount,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
 }
@@ -142,6 +268,10 @@ func lspCmd(args []string) {
 		cmd.PrintDefaults()
 		return
 	}
+	if err = applyConfig(cmd); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 	err = lspcmd.Run(lspcmd.Arguments{
 		Log:           *log,
 		GoplsLog:      *goplsLog,
@@ -2,11 +2,14 @@ package migratecmd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"os"
 	"reflect"
 	"strings"
 	"time"
 
+	"github.com/a-h/templ/cmd/templ/output"
 	"github.com/a-h/templ/cmd/templ/processor"
 	v1 "github.com/a-h/templ/parser/v1"
 	v2 "github.com/a-h/templ/parser/v2"
@@ -14,42 +17,57 @@ import (
 	"github.com/natefinch/atomic"
 )
 
-const workerCount = 4
+const defaultWorkerCount = 4
 
 type Arguments struct {
 	FileName string
 	Path     string
+	// Format of the output.
+	Format output.Format
+	// Exclude is a list of glob patterns of files and directories to skip, in addition to
+	// node_modules, vendor, .git and the patterns in the .templignore file in the path.
+	Exclude []string
+	// WorkerCount is the number of files migrated in parallel.
+	WorkerCount int
 }
 
-func Run(args Arguments) (err error) {
+func Run(ctx context.Context, args Arguments) (err error) {
+	if args.WorkerCount == 0 {
+		args.WorkerCount = defaultWorkerCount
+	}
+	out := output.NewWriter(os.Stdout, args.Format)
 	if args.FileName != "" {
-		return processSingleFile(args.FileName)
+		return processSingleFile(out, args.FileName)
 	}
-	return processPath(args.Path)
+	return processPath(ctx, out, args.Path, args.WorkerCount, processor.Options{Exclude: args.Exclude})
 }
 
-func processSingleFile(fileName string) error {
+func processSingleFile(out *output.Writer, fileName string) error {
 	start := time.Now()
 	err := migrate(fileName)
-	fmt.Printf("Migrated code for %q in %s\n", fileName, time.Since(start))
+	if out.Format != output.FormatText {
+		out.File(fileName, time.Since(start), output.StatusOf(err), err)
+	}
+	out.Textf("Migrated code for %q in %s\n", fileName, time.Since(start))
 	return err
 }
 
-func processPath(path string) (err error) {
+func processPath(ctx context.Context, out *output.Writer, path string, workerCount int, opts processor.Options) (err error) {
 	start := time.Now()
 	results := make(chan processor.Result)
-	go processor.Process(path, migrate, workerCount, results)
+	go processor.Process(ctx, path, migrate, workerCount, results, opts)
 	var successCount, errorCount int
 	for r := range results {
 		if r.Error != nil {
+			out.File(r.FileName, r.Duration, output.StatusError, r.Error)
 			err = multierror.Append(err, fmt.Errorf("%s: %w", r.FileName, r.Error))
 			errorCount++
 			continue
 		}
 		successCount++
-		fmt.Printf("%s complete in %v\n", r.FileName, r.Duration)
+		out.File(r.FileName, r.Duration, output.StatusOK, nil)
 	}
-	fmt.Printf("Migrated code for %d templates with %d errors in %s\n", successCount+errorCount, errorCount, time.Since(start))
+	out.Textf("Migrated code for %d templates with %d errors in %s\n", successCount+errorCount, errorCount, time.Since(start))
 	return err
 }
 
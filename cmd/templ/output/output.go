@@ -0,0 +1,189 @@
+// Package output writes the results of the templ commands as text for people, or in formats that
+// can be read by tools such as build dashboards and editors.
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/a-h/templ/parser/v1"
+	v2 "github.com/a-h/templ/parser/v2"
+	"github.com/hashicorp/go-multierror"
+)
+
+// Format of the output.
+type Format string
+
+const (
+	// FormatText is free-form text, e.g. "header.templ complete in 1ms".
+	FormatText Format = "text"
+	// FormatJSON writes a JSON object for each file on its own line, see Event.
+	FormatJSON Format = "json"
+	// FormatEditor writes each error as "file:line:col: message", which can be read by editors'
+	// problem matchers. Nothing is written for files without problems.
+	FormatEditor Format = "editor"
+)
+
+// Formats are the valid output formats.
+var Formats = []Format{FormatText, FormatJSON, FormatEditor}
+
+// ParseFormat returns the named format. An empty name is FormatText.
+func ParseFormat(name string) (Format, error) {
+	if name == "" {
+		return FormatText, nil
+	}
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("invalid output format %q, expected one of %v", name, Formats)
+}
+
+// Status of a processed file.
+type Status string
+
+const (
+	StatusOK    Status = "ok"
+	StatusError Status = "error"
+	// StatusUnformatted is used by templ fmt -check for files that aren't formatted.
+	StatusUnformatted Status = "unformatted"
+	// StatusStale and StatusMissing are used by templ generate -verify for generated files that are
+	// out of date.
+	StatusStale   Status = "stale"
+	StatusMissing Status = "missing"
+)
+
+// StatusOf returns StatusError if there's an error, or StatusOK if not.
+func StatusOf(err error) Status {
+	if err != nil {
+		return StatusError
+	}
+	return StatusOK
+}
+
+// Event is written in the JSON format for each file.
+type Event struct {
+	Path string `json:"path"`
+	// Duration taken to process the file, in milliseconds.
+	Duration float64 `json:"durationMs"`
+	Status   Status  `json:"status"`
+	Errors   []Error `json:"errors,omitempty"`
+}
+
+// Error in a file. Line and Col are 1-based, and are zero if the position of the error isn't known.
+type Error struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Col     int    `json:"col,omitempty"`
+}
+
+// Writer writes the results of processing files in the format. It's safe for concurrent use.
+type Writer struct {
+	Format Format
+	m      sync.Mutex
+	w      io.Writer
+}
+
+// NewWriter creates a Writer. An empty format is FormatText.
+func NewWriter(w io.Writer, format Format) *Writer {
+	if format == "" {
+		format = FormatText
+	}
+	return &Writer{
+		Format: format,
+		w:      w,
+	}
+}
+
+// File writes the result of processing the file. In the text format, only successful files are
+// written, since errors are returned by the commands and printed when they exit.
+func (w *Writer) File(path string, d time.Duration, status Status, err error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	switch w.Format {
+	case FormatJSON:
+		e := Event{
+			Path:     path,
+			Duration: float64(d) / float64(time.Millisecond),
+			Status:   status,
+			Errors:   Errors(err),
+		}
+		enc := json.NewEncoder(w.w)
+		enc.SetEscapeHTML(false)
+		enc.Encode(e)
+	case FormatEditor:
+		errs := Errors(err)
+		if len(errs) == 0 && status != StatusOK {
+			errs = append(errs, Error{Message: string(status)})
+		}
+		for _, e := range errs {
+			fmt.Fprintln(w.w, e.Location(path)+": "+e.Message)
+		}
+	default:
+		if err == nil {
+			fmt.Fprintf(w.w, "%s complete in %v\n", path, d)
+		}
+	}
+}
+
+// FileError writes an error processing the file in every format, including text. It's used for
+// errors that aren't returned by the command, e.g. in watch mode, which doesn't stop on errors.
+func (w *Writer) FileError(path string, d time.Duration, err error) {
+	if w.Format != FormatText {
+		w.File(path, d, StatusError, err)
+		return
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	fmt.Fprintf(w.w, "%s: %v\n", path, err)
+}
+
+// Textf writes a message in the text format. Nothing is written in the other formats, so that their
+// output can be read by tools.
+func (w *Writer) Textf(format string, a ...interface{}) {
+	if w.Format != FormatText {
+		return
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	fmt.Fprintf(w.w, format, a...)
+}
+
+// Location of the error in the file, e.g. "header.templ:12:4".
+func (e Error) Location(path string) string {
+	if e.Line == 0 {
+		return path
+	}
+	return fmt.Sprintf("%s:%d:%d", path, e.Line, e.Col)
+}
+
+// Errors splits the error into the errors it's made up of, with their position within the file if
+// they're parse errors.
+func Errors(err error) (errs []Error) {
+	if err == nil {
+		return nil
+	}
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		for _, err := range merr.Errors {
+			errs = append(errs, Errors(err)...)
+		}
+		return errs
+	}
+	var pe v2.ParseError
+	if errors.As(err, &pe) {
+		return []Error{{Message: pe.Message, Line: int(pe.From.Line) + 1, Col: int(pe.From.Col) + 1}}
+	}
+	var v1pe v1.ParseError
+	if errors.As(err, &v1pe) {
+		// Lines in the v1 parser are 1-based.
+		return []Error{{Message: v1pe.Message, Line: v1pe.From.Line, Col: v1pe.From.Col + 1}}
+	}
+	return []Error{{Message: strings.TrimSpace(err.Error())}}
+}
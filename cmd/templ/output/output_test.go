@@ -0,0 +1,112 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	v2 "github.com/a-h/templ/parser/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-multierror"
+)
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"", "text", "json", "editor"} {
+		if _, err := ParseFormat(name); err != nil {
+			t.Errorf("expected %q to be valid, got %v", name, err)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected an error for an invalid format")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	// The parser's positions are 0-based.
+	parseErr := v2.ParseError{
+		Message: "<div>: EOF",
+		From:    v2.Position{Index: 32, Line: 3, Col: 6},
+	}
+	tests := []struct {
+		name     string
+		err      error
+		expected []Error
+	}{
+		{
+			name:     "nil errors are empty",
+			err:      nil,
+			expected: nil,
+		},
+		{
+			name:     "parse errors have 1-based positions",
+			err:      fmt.Errorf("header.templ parsing error: %w", parseErr),
+			expected: []Error{{Message: "<div>: EOF", Line: 4, Col: 7}},
+		},
+		{
+			name:     "other errors don't have positions",
+			err:      errors.New("header_templ.go write file error"),
+			expected: []Error{{Message: "header_templ.go write file error"}},
+		},
+		{
+			name:     "multiple errors are split",
+			err:      multierror.Append(errors.New("a"), parseErr),
+			expected: []Error{{Message: "a"}, {Message: "<div>: EOF", Line: 4, Col: 7}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, Errors(tt.err)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	parseErr := fmt.Errorf("b.templ parsing error: %w", v2.ParseError{
+		Message: "<div>: EOF",
+		From:    v2.Position{Line: 3, Col: 6},
+	})
+	write := func(format Format) string {
+		var sb strings.Builder
+		w := NewWriter(&sb, format)
+		w.File("a.templ", time.Millisecond, StatusOK, nil)
+		w.File("b.templ", 2*time.Millisecond, StatusError, parseErr)
+		w.File("c.templ", 0, StatusUnformatted, nil)
+		w.FileError("d.templ", 0, errors.New("removal failed"))
+		w.Textf("Done\n")
+		return sb.String()
+	}
+	tests := []struct {
+		format   Format
+		expected string
+	}{
+		{
+			format:   FormatText,
+			expected: "a.templ complete in 1ms\nc.templ complete in 0s\nd.templ: removal failed\nDone\n",
+		},
+		{
+			format: FormatJSON,
+			expected: `{"path":"a.templ","durationMs":1,"status":"ok"}
+{"path":"b.templ","durationMs":2,"status":"error","errors":[{"message":"<div>: EOF","line":4,"col":7}]}
+{"path":"c.templ","durationMs":0,"status":"unformatted"}
+{"path":"d.templ","durationMs":0,"status":"error","errors":[{"message":"removal failed"}]}
+`,
+		},
+		{
+			format:   FormatEditor,
+			expected: "b.templ:4:7: <div>: EOF\nc.templ: unformatted\nd.templ: removal failed\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.format), func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, write(tt.format)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
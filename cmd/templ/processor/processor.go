@@ -1,25 +1,58 @@
 package processor
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io/fs"
+	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/hashicorp/go-multierror"
 )
 
 type Result struct {
 	FileName string
 	Duration time.Duration
 	Error    error
+	// Walk is true if the error is from walking the directory, rather than processing a file. The
+	// FileName is the path that couldn't be walked.
+	Walk bool
+}
+
+// Options for walking a directory.
+type Options struct {
+	// Exclude is a list of glob patterns for files and directories to skip, in addition to
+	// DefaultExclude and the patterns in the IgnoreFileName file in the directory being walked.
+	Exclude []string
 }
 
-func Process(dir string, f func(fileName string) error, workerCount int, results chan<- Result) {
+// Process calls f for each templ file in the directory, using workerCount workers, and sends the
+// results. Errors walking the directory are sent as a Result for each path that couldn't be walked.
+// Once the context is cancelled, no more files are processed.
+func Process(ctx context.Context, dir string, f func(fileName string) error, workerCount int, results chan<- Result, opts Options) {
 	defer close(results)
 	templates := make(chan string)
 	go func() {
-		if err := getTemplates(dir, templates); err != nil {
-			results <- Result{Error: err}
+		// Close the templates channel after any error is sent, so that the results channel is still open.
+		defer close(templates)
+		err := Walk(ctx, dir, opts, func(fileName string) error {
+			if !strings.HasSuffix(fileName, ".templ") {
+				return nil
+			}
+			select {
+			case templates <- fileName:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		for _, r := range walkResults(dir, err) {
+			results <- r
 		}
 	}()
 	var wg sync.WaitGroup
@@ -40,12 +73,143 @@ func Process(dir string, f func(fileName string) error, workerCount int, results
 	wg.Wait()
 }
 
-func getTemplates(srcPath string, output chan<- string) (err error) {
-	defer close(output)
-	return filepath.Walk(srcPath, func(currentPath string, info fs.FileInfo, err error) error {
-		if !info.IsDir() && strings.HasSuffix(currentPath, ".templ") {
-			output <- currentPath
-		}
+// walkResults splits the error returned by Walk into a Result for each path that couldn't be walked.
+// Errors that aren't about a path, such as the context being cancelled, are for the directory.
+func walkResults(dir string, err error) (results []Result) {
+	if err == nil {
 		return nil
+	}
+	errs := []error{err}
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		errs = merr.Errors
+	}
+	for _, err := range errs {
+		r := Result{FileName: dir, Error: err, Walk: true}
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			r.FileName, r.Error = pathErr.Path, pathErr.Err
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// Walk calls f for each file in the directory, skipping files and directories that are excluded.
+// Symbolic links to files are included, but symbolic links to directories aren't followed, so that
+// links can't cause files to be processed twice, or the walk to never end.
+//
+// It's an error if the directory doesn't exist. Directories within it that can't be read are
+// skipped, and the errors are returned after the rest of the directory has been walked. The walk
+// stops if f returns an error, or the context is cancelled.
+func Walk(ctx context.Context, dir string, opts Options, f func(fileName string) error) (err error) {
+	ignore, err := loadIgnore(dir, opts.Exclude)
+	if err != nil {
+		return err
+	}
+	var walkErr error
+	err = filepath.WalkDir(dir, func(currentPath string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			if currentPath == dir {
+				return err
+			}
+			// Files can be removed while the directory is being walked.
+			if !os.IsNotExist(err) {
+				walkErr = multierror.Append(walkErr, err)
+			}
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if currentPath != dir && ignore.match(dir, currentPath, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			info, err := os.Stat(currentPath)
+			if err != nil || !info.Mode().IsRegular() {
+				// Skip broken links, and links to directories.
+				return nil
+			}
+		}
+		return f(currentPath)
 	})
+	if err != nil {
+		return err
+	}
+	return walkErr
+}
+
+// IgnoreFileName is the name of the file in the directory being walked that lists glob patterns of
+// files and directories to skip, one per line. Blank lines, and lines starting with #, are ignored.
+const IgnoreFileName = ".templignore"
+
+// DefaultExclude are the directories that are always skipped, since they contain dependencies and
+// version control data rather than the project's templates.
+var DefaultExclude = []string{".git", "node_modules", "vendor"}
+
+// ignore matches files and directories against glob patterns. Patterns that contain a slash, other
+// than a trailing slash, are matched against the path relative to the directory being walked, and
+// other patterns are matched against the name of the file or directory. Patterns ending with a slash
+// only match directories.
+type ignore []string
+
+func loadIgnore(dir string, exclude []string) (i ignore, err error) {
+	i = append(i, DefaultExclude...)
+	i = append(i, exclude...)
+	// Single files can be walked, and don't have an ignore file.
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		fileName := filepath.Join(dir, IgnoreFileName)
+		b, err := os.ReadFile(fileName)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s read error: %w", fileName, err)
+		}
+		for _, line := range strings.Split(string(b), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			i = append(i, line)
+		}
+	}
+	for _, pattern := range i {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+	}
+	return i, nil
+}
+
+func (i ignore) match(dir, fileName string, isDir bool) bool {
+	rel, err := filepath.Rel(dir, fileName)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range i {
+		if strings.HasSuffix(pattern, "/") {
+			if !isDir {
+				continue
+			}
+			pattern = strings.TrimSuffix(pattern, "/")
+		}
+		name := path.Base(rel)
+		if strings.Contains(pattern, "/") {
+			pattern = strings.TrimPrefix(pattern, "/")
+			name = rel
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,192 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		fileName := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fileName, []byte("package test\n"), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+}
+
+func process(t *testing.T, ctx context.Context, dir string, opts Options) (fileNames []string, err error) {
+	t.Helper()
+	results := make(chan Result)
+	go Process(ctx, dir, func(fileName string) error { return nil }, 2, results, opts)
+	for r := range results {
+		if r.Error != nil {
+			err = r.Error
+			continue
+		}
+		rel, relErr := filepath.Rel(dir, r.FileName)
+		if relErr != nil {
+			t.Fatalf("failed to get relative path: %v", relErr)
+		}
+		fileNames = append(fileNames, filepath.ToSlash(rel))
+	}
+	sort.Strings(fileNames)
+	return fileNames, err
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      []string
+		ignoreFile string
+		exclude    []string
+		expected   []string
+	}{
+		{
+			name:     "only templ files are processed",
+			files:    []string{"a.templ", "a_templ.go", "sub/b.templ"},
+			expected: []string{"a.templ", "sub/b.templ"},
+		},
+		{
+			name:     "dependencies and version control directories are skipped",
+			files:    []string{"a.templ", "node_modules/x/b.templ", "vendor/c.templ", ".git/d.templ", "sub/vendor/e.templ"},
+			expected: []string{"a.templ"},
+		},
+		{
+			name:     "files and directories matching exclude patterns are skipped",
+			files:    []string{"a.templ", "a_test.templ", "sub/b_test.templ", "testdata/c.templ"},
+			exclude:  []string{"*_test.templ", "testdata"},
+			expected: []string{"a.templ"},
+		},
+		{
+			name:     "patterns with a slash are relative to the directory",
+			files:    []string{"a/b.templ", "a/c/b.templ", "c/b.templ"},
+			exclude:  []string{"/c/b.templ", "a/c"},
+			expected: []string{"a/b.templ"},
+		},
+		{
+			name:     "patterns ending with a slash only match directories",
+			files:    []string{"gen.templ", "gen/a.templ", "sub/gen/b.templ"},
+			exclude:  []string{"gen*/"},
+			expected: []string{"gen.templ"},
+		},
+		{
+			name:       "patterns are read from the ignore file",
+			files:      []string{"a.templ", "generated/b.templ", "c.templ"},
+			ignoreFile: "# Generated templates.\n\ngenerated\nc.templ\n",
+			expected:   []string{"a.templ"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files...)
+			if tt.ignoreFile != "" {
+				if err := os.WriteFile(filepath.Join(dir, IgnoreFileName), []byte(tt.ignoreFile), 0644); err != nil {
+					t.Fatalf("failed to write ignore file: %v", err)
+				}
+			}
+			actual, err := process(t, context.Background(), dir, Options{Exclude: tt.exclude})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestProcessInvalidPattern(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "a.templ")
+	if _, err := process(t, context.Background(), dir, Options{Exclude: []string{"["}}); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestProcessSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "a.templ", "sub/b.templ")
+	if err := os.Symlink(filepath.Join(dir, "a.templ"), filepath.Join(dir, "link.templ")); err != nil {
+		t.Skipf("symlinks aren't supported: %v", err)
+	}
+	// A link to the parent directory would be walked forever if it was followed.
+	if err := os.Symlink(dir, filepath.Join(dir, "sub", "parent")); err != nil {
+		t.Fatalf("failed to create link: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "missing.templ"), filepath.Join(dir, "broken.templ")); err != nil {
+		t.Fatalf("failed to create link: %v", err)
+	}
+	actual, err := process(t, context.Background(), dir, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"a.templ", "link.templ", "sub/b.templ"}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestProcessMissingDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	results := make(chan Result)
+	go Process(context.Background(), dir, func(fileName string) error { return nil }, 2, results, Options{})
+	var actual []Result
+	for r := range results {
+		actual = append(actual, r)
+	}
+	if len(actual) != 1 {
+		t.Fatalf("expected one result, got %v", actual)
+	}
+	if !os.IsNotExist(actual[0].Error) {
+		t.Errorf("expected a not exist error, got %v", actual[0].Error)
+	}
+	if actual[0].FileName != dir || !actual[0].Walk {
+		t.Errorf("expected a walk error for %s, got %+v", dir, actual[0])
+	}
+}
+
+func TestProcessUnreadableDirectory(t *testing.T) {
+	if os.Getuid() == 0 {
+		t.Skip("directories can't be made unreadable for root")
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, "a.templ", "private/b.templ")
+	private := filepath.Join(dir, "private")
+	if err := os.Chmod(private, 0); err != nil {
+		t.Fatalf("failed to change permissions: %v", err)
+	}
+	defer os.Chmod(private, 0755)
+	actual, err := process(t, context.Background(), dir, Options{})
+	if err == nil {
+		t.Error("expected an error")
+	}
+	if diff := cmp.Diff([]string{"a.templ"}, actual); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestProcessCancelled(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "a.templ", "b.templ")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	actual, err := process(t, ctx, dir, Options{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if len(actual) != 0 {
+		t.Errorf("expected no files to be processed, got %v", actual)
+	}
+}
@@ -0,0 +1,94 @@
+package templ
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// ErrorBoundary renders the child component, or the component returned by the fallback function if
+// the child returns an error or panics. The child is rendered into a buffer, so that none of its output
+// is written if it fails. The error is reported to the hook added to the context by WithErrorHook.
+//
+// If the context is cancelled, the error is returned instead of rendering the fallback.
+func ErrorBoundary(child Component, fallback func(err error) Component) Component {
+	return ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		buf := getBuffer()
+		defer putBuffer(buf)
+		if err = renderRecover(ctx, child, buf); err == nil {
+			_, err = buf.WriteTo(w)
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		if hook, ok := ctx.Value(contextKeyErrorHook).(func(ctx context.Context, err error)); ok {
+			hook(ctx, err)
+		}
+		if fallback == nil {
+			return nil
+		}
+		return fallback(err).Render(ctx, w)
+	})
+}
+
+// PanicError is returned when a component panics.
+type PanicError struct {
+	// Value passed to panic.
+	Value interface{}
+	// Stack trace of the goroutine that panicked.
+	Stack []byte
+	// File and Line of the template code that panicked, if known. If the code was generated with line
+	// directives, this is the position in the templ file, otherwise it's the position in the generated
+	// Go file.
+	File string
+	Line int
+}
+
+func (pe PanicError) Error() string {
+	if pe.File != "" {
+		return fmt.Sprintf("templ: component panicked at %s:%d: %v", pe.File, pe.Line, pe.Value)
+	}
+	return fmt.Sprintf("templ: component panicked: %v", pe.Value)
+}
+
+// renderRecover renders the component, returning a PanicError if it panics.
+func renderRecover(ctx context.Context, c Component, w io.Writer) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			file, line := panicLocation()
+			err = PanicError{Value: r, Stack: debug.Stack(), File: file, Line: line}
+		}
+	}()
+	return c.Render(ctx, w)
+}
+
+// panicLocation returns the position of the template code closest to the panic, when called from a
+// deferred function.
+func panicLocation() (file string, line int) {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if strings.HasSuffix(f.File, ".templ") || strings.HasSuffix(f.File, "_templ.go") {
+			return f.File, f.Line
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
+
+type errorHookContextKey string
+
+var contextKeyErrorHook = errorHookContextKey("errorHook")
+
+// WithErrorHook adds a function to the context that's called with the errors handled by ErrorBoundary
+// components, e.g. to log them.
+func WithErrorHook(ctx context.Context, hook func(ctx context.Context, err error)) context.Context {
+	return context.WithValue(ctx, contextKeyErrorHook, hook)
+}
@@ -0,0 +1,119 @@
+package templ
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestErrorBoundary(t *testing.T) {
+	errChild := errors.New("child error")
+	failingChild := ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		io.WriteString(w, "Partial")
+		return errChild
+	})
+	panickingChild := ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		io.WriteString(w, "Partial")
+		panic("child panic")
+	})
+	fallback := func(err error) Component {
+		return text("Fallback")
+	}
+
+	tests := []struct {
+		name          string
+		input         Component
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "children that succeed are rendered",
+			input:    ErrorBoundary(text("Child"), fallback),
+			expected: "Child",
+		},
+		{
+			name:          "children that return errors are replaced with the fallback",
+			input:         ErrorBoundary(failingChild, fallback),
+			expected:      "Fallback",
+			expectedError: errChild,
+		},
+		{
+			name:          "children that panic are replaced with the fallback",
+			input:         ErrorBoundary(panickingChild, fallback),
+			expected:      "Fallback",
+			expectedError: PanicError{Value: "child panic"},
+		},
+		{
+			name:          "nothing is rendered without a fallback",
+			input:         ErrorBoundary(failingChild, nil),
+			expected:      "",
+			expectedError: errChild,
+		},
+		{
+			name: "the error is passed to the fallback",
+			input: ErrorBoundary(failingChild, func(err error) Component {
+				return text(err.Error())
+			}),
+			expected:      "child error",
+			expectedError: errChild,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []error
+			ctx := WithErrorHook(context.Background(), func(ctx context.Context, err error) {
+				reported = append(reported, err)
+			})
+			var sb strings.Builder
+			if err := tt.input.Render(ctx, &sb); err != nil {
+				t.Fatalf("failed to render: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, sb.String()); diff != "" {
+				t.Error(diff)
+			}
+			var expectedReported []string
+			if tt.expectedError != nil {
+				expectedReported = append(expectedReported, tt.expectedError.Error())
+			}
+			var actualReported []string
+			for _, err := range reported {
+				actualReported = append(actualReported, err.Error())
+			}
+			if diff := cmp.Diff(expectedReported, actualReported); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	t.Run("errors are returned if the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var sb strings.Builder
+		err := ErrorBoundary(failingChild, fallback).Render(ctx, &sb)
+		if !errors.Is(err, errChild) {
+			t.Errorf("expected the child error, got %v", err)
+		}
+		if sb.Len() != 0 {
+			t.Errorf("expected no output, got %q", sb.String())
+		}
+	})
+	t.Run("panics include the stack trace", func(t *testing.T) {
+		var reported error
+		ctx := WithErrorHook(context.Background(), func(ctx context.Context, err error) {
+			reported = err
+		})
+		ErrorBoundary(panickingChild, fallback).Render(ctx, io.Discard)
+		var pe PanicError
+		if !errors.As(reported, &pe) {
+			t.Fatalf("expected a PanicError, got %v", reported)
+		}
+		if !strings.Contains(string(pe.Stack), "TestErrorBoundary") {
+			t.Errorf("expected the stack trace to include the test, got %s", pe.Stack)
+		}
+	})
+}
@@ -18,22 +18,7 @@ func headerTemplate(name string) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
-		_, err = io.WriteString(w, "<header")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " data-testid=\"headerTemplate\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<h1>")
+		_, err = io.WriteString(w, `<header data-testid="headerTemplate"><h1>`)
 		if err != nil {
 			return err
 		}
@@ -42,11 +27,7 @@ func headerTemplate(name string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</h1>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</header>")
+		_, err = io.WriteString(w, `</h1></header>`)
 		if err != nil {
 			return err
 		}
@@ -60,28 +41,7 @@ func footerTemplate() templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_2 := ctx
 		ctx = templ.ClearChildren(var_2)
-		// Element (standard)
-		_, err = io.WriteString(w, "<footer")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " data-testid=\"footerTemplate\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<div>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_3 := `&copy; `
-		_, err = io.WriteString(w, var_3)
+		_, err = io.WriteString(w, `<footer data-testid="footerTemplate"><div>&copy; `)
 		if err != nil {
 			return err
 		}
@@ -90,11 +50,7 @@ func footerTemplate() templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</div>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</footer>")
+		_, err = io.WriteString(w, `</div></footer>`)
 		if err != nil {
 			return err
 		}
@@ -106,98 +62,9 @@ func navTemplate() templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
-		var_4 := ctx
-		ctx = templ.ClearChildren(var_4)
-		// Element (standard)
-		_, err = io.WriteString(w, "<nav")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " data-testid=\"navTemplate\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<ul>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<li>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<a")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " href=\"/\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_5 := `Home`
-		_, err = io.WriteString(w, var_5)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</a>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</li>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<li>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<a")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " href=\"/posts\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_6 := `Posts`
-		_, err = io.WriteString(w, var_6)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</a>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</li>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</ul>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</nav>")
+		var_3 := ctx
+		ctx = templ.ClearChildren(var_3)
+		_, err = io.WriteString(w, `<nav data-testid="navTemplate"><ul><li><a href="/">Home</a></li><li><a href="/posts">Posts</a></li></ul></nav>`)
 		if err != nil {
 			return err
 		}
@@ -209,20 +76,9 @@ func layout(name string) templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
-		var_7 := ctx
-		ctx = templ.ClearChildren(var_7)
-		// Element (standard)
-		_, err = io.WriteString(w, "<html>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<head>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<title>")
+		var_4 := ctx
+		ctx = templ.ClearChildren(var_4)
+		_, err = io.WriteString(w, `<html><head><title>`)
 		if err != nil {
 			return err
 		}
@@ -231,16 +87,7 @@ func layout(name string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</title>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</head>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<body>")
+		_, err = io.WriteString(w, `</title></head><body>`)
 		if err != nil {
 			return err
 		}
@@ -254,21 +101,16 @@ func layout(name string) templ.Component {
 		if err != nil {
 			return err
 		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<main>")
+		_, err = io.WriteString(w, `<main>`)
 		if err != nil {
 			return err
 		}
 		// Children
-		err = templ.GetChildren(var_7).Render(ctx, w)
+		err = templ.GetChildren(var_4).Render(ctx, w)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</main>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</body>")
+		_, err = io.WriteString(w, `</main></body>`)
 		if err != nil {
 			return err
 		}
@@ -277,7 +119,7 @@ func layout(name string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</html>")
+		_, err = io.WriteString(w, `</html>`)
 		if err != nil {
 			return err
 		}
@@ -289,49 +131,15 @@ func postsTemplate(posts []Post) templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
-		var_8 := ctx
-		ctx = templ.ClearChildren(var_8)
-		// Element (standard)
-		_, err = io.WriteString(w, "<div")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " data-testid=\"postsTemplate\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
+		var_5 := ctx
+		ctx = templ.ClearChildren(var_5)
+		_, err = io.WriteString(w, `<div data-testid="postsTemplate">`)
 		if err != nil {
 			return err
 		}
 		// For
 		for _, p := range posts {
-			// Element (standard)
-			_, err = io.WriteString(w, "<div")
-			if err != nil {
-				return err
-			}
-			// Element Attributes
-			_, err = io.WriteString(w, " data-testid=\"postsTemplatePost\"")
-			if err != nil {
-				return err
-			}
-			_, err = io.WriteString(w, ">")
-			if err != nil {
-				return err
-			}
-			// Element (standard)
-			_, err = io.WriteString(w, "<div")
-			if err != nil {
-				return err
-			}
-			// Element Attributes
-			_, err = io.WriteString(w, " data-testid=\"postsTemplatePostName\"")
-			if err != nil {
-				return err
-			}
-			_, err = io.WriteString(w, ">")
+			_, err = io.WriteString(w, `<div data-testid="postsTemplatePost"><div data-testid="postsTemplatePostName">`)
 			if err != nil {
 				return err
 			}
@@ -340,21 +148,7 @@ func postsTemplate(posts []Post) templ.Component {
 			if err != nil {
 				return err
 			}
-			_, err = io.WriteString(w, "</div>")
-			if err != nil {
-				return err
-			}
-			// Element (standard)
-			_, err = io.WriteString(w, "<div")
-			if err != nil {
-				return err
-			}
-			// Element Attributes
-			_, err = io.WriteString(w, " data-testid=\"postsTemplatePostAuthor\"")
-			if err != nil {
-				return err
-			}
-			_, err = io.WriteString(w, ">")
+			_, err = io.WriteString(w, `</div><div data-testid="postsTemplatePostAuthor">`)
 			if err != nil {
 				return err
 			}
@@ -363,16 +157,12 @@ func postsTemplate(posts []Post) templ.Component {
 			if err != nil {
 				return err
 			}
-			_, err = io.WriteString(w, "</div>")
-			if err != nil {
-				return err
-			}
-			_, err = io.WriteString(w, "</div>")
+			_, err = io.WriteString(w, `</div></div>`)
 			if err != nil {
 				return err
 			}
 		}
-		_, err = io.WriteString(w, "</div>")
+		_, err = io.WriteString(w, `</div>`)
 		if err != nil {
 			return err
 		}
@@ -384,37 +174,17 @@ func home() templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
-		var_9 := ctx
-		ctx = templ.ClearChildren(var_9)
+		var_6 := ctx
+		ctx = templ.ClearChildren(var_6)
 		// TemplElement
-		var_10 := templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
-			// Element (standard)
-			_, err = io.WriteString(w, "<div")
-			if err != nil {
-				return err
-			}
-			// Element Attributes
-			_, err = io.WriteString(w, " data-testid=\"homeTemplate\"")
-			if err != nil {
-				return err
-			}
-			_, err = io.WriteString(w, ">")
-			if err != nil {
-				return err
-			}
-			// Text
-			var_11 := `Welcome to my website.`
-			_, err = io.WriteString(w, var_11)
-			if err != nil {
-				return err
-			}
-			_, err = io.WriteString(w, "</div>")
+		var_7 := templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+			_, err = io.WriteString(w, `<div data-testid="homeTemplate">Welcome to my website.</div>`)
 			if err != nil {
 				return err
 			}
 			return err
 		})
-		err = layout("Home").Render(templ.WithChildren(ctx, var_10), w)
+		err = layout("Home").Render(templ.WithChildren(ctx, var_7), w)
 		if err != nil {
 			return err
 		}
@@ -426,10 +196,10 @@ func posts(posts []Post) templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
-		var_12 := ctx
-		ctx = templ.ClearChildren(var_12)
+		var_8 := ctx
+		ctx = templ.ClearChildren(var_8)
 		// TemplElement
-		var_13 := templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		var_9 := templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 			// TemplElement
 			err = postsTemplate(posts).Render(ctx, w)
 			if err != nil {
@@ -437,7 +207,7 @@ func posts(posts []Post) templ.Component {
 			}
 			return err
 		})
-		err = layout("Posts").Render(templ.WithChildren(ctx, var_13), w)
+		err = layout("Posts").Render(templ.WithChildren(ctx, var_9), w)
 		if err != nil {
 			return err
 		}
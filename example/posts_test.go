@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -12,95 +11,43 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/a-h/templ/templtest"
 )
 
 func TestHeader(t *testing.T) {
-	// Pipe the rendered template into goquery.
-	r, w := io.Pipe()
-	go func() {
-		headerTemplate("Posts").Render(context.Background(), w)
-		w.Close()
-	}()
-	doc, err := goquery.NewDocumentFromReader(r)
-	if err != nil {
-		t.Fatalf("failed to read template: %v", err)
-	}
+	doc := templtest.Render(t, headerTemplate("Posts"))
 	// Expect the component to include a testid.
-	if doc.Find(`[data-testid="headerTemplate"]`).Length() == 0 {
-		t.Error("expected data-testid attribute to be rendered, but it wasn't")
-	}
+	doc.AssertExists(templtest.ByTestID("headerTemplate"))
 	// Expect the page name to be set correctly.
-	expectedPageName := "Posts"
-	if actualPageName := doc.Find("h1").Text(); actualPageName != expectedPageName {
-		t.Errorf("expected page name %q, got %q", expectedPageName, actualPageName)
-	}
+	doc.AssertText("h1", "Posts")
 }
 
 func TestFooter(t *testing.T) {
-	// Pipe the rendered template into goquery.
-	r, w := io.Pipe()
-	go func() {
-		footerTemplate().Render(context.Background(), w)
-		w.Close()
-	}()
-	doc, err := goquery.NewDocumentFromReader(r)
-	if err != nil {
-		t.Fatalf("failed to read template: %v", err)
-	}
+	doc := templtest.Render(t, footerTemplate())
 	// Expect the component to include a testid.
-	if doc.Find(`[data-testid="footerTemplate"]`).Length() == 0 {
-		t.Error("expected data-testid attribute to be rendered, but it wasn't")
-	}
+	doc.AssertExists(templtest.ByTestID("footerTemplate"))
 	// Expect the copyright notice to include the current year.
-	expectedCopyrightNotice := fmt.Sprintf("© %d", time.Now().Year())
-	if actualCopyrightNotice := doc.Find("div").Text(); actualCopyrightNotice != expectedCopyrightNotice {
-		t.Errorf("expected copyright notice %q, got %q", expectedCopyrightNotice, actualCopyrightNotice)
-	}
+	doc.AssertText("div", fmt.Sprintf("© %d", time.Now().Year()))
 }
 
 func TestNav(t *testing.T) {
-	r, w := io.Pipe()
-	go func() {
-		navTemplate().Render(context.Background(), w)
-		w.Close()
-	}()
-	doc, err := goquery.NewDocumentFromReader(r)
-	if err != nil {
-		t.Fatalf("failed to read template: %v", err)
-	}
+	doc := templtest.Render(t, navTemplate())
 	// Expect the component to include a testid.
-	if doc.Find(`[data-testid="navTemplate"]`).Length() == 0 {
-		t.Error("expected data-testid attribute to be rendered, but it wasn't")
-	}
+	doc.AssertExists(templtest.ByTestID("navTemplate"))
+	// Expect the links to be named.
+	doc.AssertAccessibleName(`a[href="/posts"]`, "Posts")
 }
 
 func TestHome(t *testing.T) {
-	r, w := io.Pipe()
-	go func() {
-		home().Render(context.Background(), w)
-		w.Close()
-	}()
-	doc, err := goquery.NewDocumentFromReader(r)
-	if err != nil {
-		t.Fatalf("failed to read template: %v", err)
-	}
+	doc := templtest.Render(t, home())
 	// Expect the page title to be set correctly.
-	expectedTitle := "Home"
-	if actualTitle := doc.Find("title").Text(); actualTitle != expectedTitle {
-		t.Errorf("expected title name %q, got %q", expectedTitle, actualTitle)
-	}
+	doc.AssertText("title", "Home")
 	// Expect the header to be rendered.
-	if doc.Find(`[data-testid="headerTemplate"]`).Length() == 0 {
-		t.Error("expected data-testid attribute to be rendered, but it wasn't")
-	}
+	doc.AssertExists(templtest.ByTestID("headerTemplate"))
 	// Expect the navigation to be rendered.
-	if doc.Find(`[data-testid="navTemplate"]`).Length() == 0 {
-		t.Error("expected nav to be rendered, but it wasn't")
-	}
+	doc.AssertExists(templtest.ByTestID("navTemplate"))
 	// Expect the home template be rendered.
-	if doc.Find(`[data-testid="homeTemplate"]`).Length() == 0 {
-		t.Error("expected homeTemplate to be rendered, but it wasn't")
-	}
+	doc.AssertExists(templtest.ByTestID("homeTemplate"))
 }
 
 func TestPosts(t *testing.T) {
@@ -108,45 +55,26 @@ func TestPosts(t *testing.T) {
 		{Name: "Name1", Author: "Author1"},
 		{Name: "Name2", Author: "Author2"},
 	}
-	r, w := io.Pipe()
-	go func() {
-		posts(testPosts).Render(context.Background(), w)
-		w.Close()
-	}()
-	doc, err := goquery.NewDocumentFromReader(r)
-	if err != nil {
-		t.Fatalf("failed to read template: %v", err)
-	}
+	doc := templtest.Render(t, posts(testPosts))
 	// Assert.
 	// Expect the page title to be set correctly.
-	expectedTitle := "Posts"
-	if actualTitle := doc.Find("title").Text(); actualTitle != expectedTitle {
-		t.Errorf("expected title name %q, got %q", expectedTitle, actualTitle)
-	}
+	doc.AssertText("title", "Posts")
 	// Expect the header to be rendered.
-	if doc.Find(`[data-testid="headerTemplate"]`).Length() == 0 {
-		t.Error("expected data-testid attribute to be rendered, but it wasn't")
-	}
+	doc.AssertExists(templtest.ByTestID("headerTemplate"))
 	// Expect the navigation to be rendered.
-	if doc.Find(`[data-testid="navTemplate"]`).Length() == 0 {
-		t.Error("expected nav to be rendered, but it wasn't")
-	}
+	doc.AssertExists(templtest.ByTestID("navTemplate"))
 	// Expect the posts to be rendered.
-	if doc.Find(`[data-testid="postsTemplate"]`).Length() == 0 {
-		t.Error("expected posts to be rendered, but it wasn't")
-	}
+	doc.AssertExists(templtest.ByTestID("postsTemplate"))
 	// Expect both posts to be rendered.
-	if actualPostCount := doc.Find(`[data-testid="postsTemplatePost"]`).Length(); actualPostCount != len(testPosts) {
-		t.Fatalf("expected %d posts to be rendered, found %d", len(testPosts), actualPostCount)
-	}
+	doc.AssertCount(templtest.ByTestID("postsTemplatePost"), len(testPosts))
 	// Expect the posts to contain the author name.
-	doc.Find(`[data-testid="postsTemplatePost"]`).Each(func(index int, sel *goquery.Selection) {
+	doc.Find(templtest.ByTestID("postsTemplatePost")).Each(func(index int, sel *goquery.Selection) {
 		expectedName := testPosts[index].Name
-		if actualName := sel.Find(`[data-testid="postsTemplatePostName"]`).Text(); actualName != expectedName {
+		if actualName := sel.Find(templtest.ByTestID("postsTemplatePostName")).Text(); actualName != expectedName {
 			t.Errorf("expected name %q, got %q", actualName, expectedName)
 		}
 		expectedAuthor := testPosts[index].Author
-		if actualAuthor := sel.Find(`[data-testid="postsTemplatePostAuthor"]`).Text(); actualAuthor != expectedAuthor {
+		if actualAuthor := sel.Find(templtest.ByTestID("postsTemplatePostAuthor")).Text(); actualAuthor != expectedAuthor {
 			t.Errorf("expected author %q, got %q", actualAuthor, expectedAuthor)
 		}
 	})
@@ -7,30 +7,54 @@ import (
 	"html"
 	"io"
 	"reflect"
-	"runtime/debug"
+	"strconv"
 	"strings"
 
 	"github.com/a-h/templ"
 	"github.com/a-h/templ/parser/v2"
 )
 
-func Generate(template parser.TemplateFile, w io.Writer) (sm *parser.SourceMap, err error) {
+// GenerateOpt is an option for Generate.
+type GenerateOpt func(g *generator)
+
+// WithLineDirectives adds line directives around each Go expression, so that Go compiler errors and stack
+// traces report the expression's position in templFileName rather than its position in goFileName.
+// Relative file names are relative to the directory that contains the generated Go file.
+func WithLineDirectives(templFileName, goFileName string) GenerateOpt {
+	return func(g *generator) {
+		g.lineDirectives = true
+		g.templFileName = templFileName
+		g.goFileName = goFileName
+	}
+}
+
+func Generate(template parser.TemplateFile, w io.Writer, opts ...GenerateOpt) (sm *parser.SourceMap, err error) {
 	g := generator{
 		tf:        template,
 		w:         NewRangeWriter(w),
 		sourceMap: parser.NewSourceMap(),
 	}
+	for _, opt := range opts {
+		opt(&g)
+	}
+	g.w.beforeWrite = g.flushStringLiterals
 	err = g.generate()
 	sm = g.sourceMap
 	return
 }
 
 type generator struct {
-	tf          parser.TemplateFile
-	w           *RangeWriter
-	sourceMap   *parser.SourceMap
-	variableID  int
-	childrenVar string
+	tf             parser.TemplateFile
+	w              *RangeWriter
+	sourceMap      *parser.SourceMap
+	variableID     int
+	childrenVar    string
+	lineDirectives bool
+	templFileName  string
+	goFileName     string
+	// stringLiterals is constant output that hasn't been written yet.
+	stringLiterals            strings.Builder
+	stringLiteralsIndentLevel int
 }
 
 func (g *generator) generate() (err error) {
@@ -49,28 +73,49 @@ func (g *generator) generate() (err error) {
 	return err
 }
 
-// Binary builds set this version string. goreleaser sets the value using Go build ldflags.
-var version string
-
-// Source builds use this value. When installed using `go install github.com/a-h/templ/cmd/templ@latest` the `version` variable is empty, but
-// the debug.ReadBuildInfo return value provides the package version number installed by `go install`
-func goInstallVersion() string {
-	info, ok := debug.ReadBuildInfo()
-	if !ok {
-		return "unknown"
+// writeExpression writes the Go expression, and adds it to the source map.
+func (g *generator) writeExpression(e parser.Expression) (r parser.Range, err error) {
+	// Expressions created by the generator aren't in the templ file.
+	hasPosition := e.Range.From != e.Range.To
+	if g.lineDirectives && hasPosition {
+		// /*line template.templ:1:1*/
+		if _, err = g.w.Write(lineDirective(g.templFileName, e.Range.From.Line+1, e.Range.From.Col+1)); err != nil {
+			return
+		}
+	}
+	if r, err = g.w.Write(e.Value); err != nil {
+		return
+	}
+	g.sourceMap.Add(e, r)
+	if g.lineDirectives && hasPosition {
+		// Point the rest of the generated code back at the Go file.
+		// /*line template_templ.go:10:20*/
+		if _, err = g.w.Write(g.goLineDirective()); err != nil {
+			return
+		}
 	}
-	return info.Main.Version
+	return
 }
 
-func getVersion() string {
-	if version != "" {
-		return version
+// goLineDirective returns a line directive for the position immediately after itself in the Go file.
+func (g *generator) goLineDirective() (directive string) {
+	line, col := g.w.Current.Line+1, uint32(g.w.Current.Index-g.w.lineIndex)+1
+	// The column depends on the length of the directive, which depends on the column.
+	for {
+		next := lineDirective(g.goFileName, line, col+uint32(len(directive)))
+		if next == directive {
+			return directive
+		}
+		directive = next
 	}
-	return goInstallVersion()
+}
+
+func lineDirective(fileName string, line, col uint32) string {
+	return fmt.Sprintf("/*line %s:%d:%d*/", fileName, line, col)
 }
 
 func (g *generator) writeCodeGeneratedComment() error {
-	_, err := g.w.Write(fmt.Sprintf("// Code generated by templ@%s DO NOT EDIT.\n\n", getVersion()))
+	_, err := g.w.Write(fmt.Sprintf("// Code generated by templ@%s DO NOT EDIT.\n\n", templ.GetVersion()))
 	return err
 }
 
@@ -159,7 +204,6 @@ func (g *generator) writeTemplateNodes() error {
 }
 
 func (g *generator) writeCSS(n parser.CSSTemplate) error {
-	var r parser.Range
 	var err error
 	var indentLevel int
 
@@ -167,10 +211,9 @@ func (g *generator) writeCSS(n parser.CSSTemplate) error {
 	if _, err = g.w.Write("func "); err != nil {
 		return err
 	}
-	if r, err = g.w.Write(n.Name.Value); err != nil {
+	if _, err = g.writeExpression(n.Name); err != nil {
 		return err
 	}
-	g.sourceMap.Add(n.Name, r)
 	// () templ.CSSClass {
 	if _, err = g.w.Write("() templ.CSSClass {\n"); err != nil {
 		return err
@@ -193,10 +236,9 @@ func (g *generator) writeCSS(n parser.CSSTemplate) error {
 				if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf("templCSSBuilder.WriteString(string(templ.SanitizeCSS(`%s`, ", p.Name)); err != nil {
 					return err
 				}
-				if r, err = g.w.Write(p.Value.Expression.Value); err != nil {
+				if _, err = g.writeExpression(p.Value.Expression); err != nil {
 					return err
 				}
-				g.sourceMap.Add(p.Value.Expression, r)
 				if _, err = g.w.Write(")))\n"); err != nil {
 					return err
 				}
@@ -239,11 +281,9 @@ func (g *generator) writeGoExpression(n parser.GoExpression) (err error) {
 	if _, err = g.w.WriteIndent(0, "// GoExpression\n"); err != nil {
 		return err
 	}
-	r, err := g.w.Write(n.Expression.Value)
-	if err != nil {
+	if _, err := g.writeExpression(n.Expression); err != nil {
 		return err
 	}
-	g.sourceMap.Add(n.Expression, r)
 	if _, err = g.w.WriteIndent(0, "\n\n"); err != nil {
 		return err
 	}
@@ -251,7 +291,6 @@ func (g *generator) writeGoExpression(n parser.GoExpression) (err error) {
 }
 
 func (g *generator) writeTemplate(t parser.HTMLTemplate) error {
-	var r parser.Range
 	var err error
 	var indentLevel int
 
@@ -260,10 +299,9 @@ func (g *generator) writeTemplate(t parser.HTMLTemplate) error {
 		return err
 	}
 	// (r *Receiver) Name(params []string)
-	if r, err = g.w.Write(t.Expression.Value); err != nil {
+	if _, err = g.writeExpression(t.Expression); err != nil {
 		return err
 	}
-	g.sourceMap.Add(t.Expression, r)
 	// templ.Component {
 	if _, err = g.w.Write(" templ.Component {\n"); err != nil {
 		return err
@@ -398,6 +436,8 @@ func (g *generator) writeNode(indentLevel int, current parser.Node) error {
 	switch n := current.(type) {
 	case parser.DocType:
 		g.writeDocType(indentLevel, n)
+	case parser.Comment:
+		g.writeComment(indentLevel, n)
 	case parser.Element:
 		g.writeElement(indentLevel, n)
 	case parser.ChildrenExpression:
@@ -427,15 +467,16 @@ func (g *generator) writeNode(indentLevel int, current parser.Node) error {
 }
 
 func (g *generator) writeDocType(indentLevel int, n parser.DocType) (err error) {
-	if _, err = g.w.WriteIndent(indentLevel, "// DocType\n"); err != nil {
-		return err
-	}
-	if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf("_, err = io.WriteString(w, `<!doctype %s>`)\n", n.Value)); err != nil {
-		return err
-	}
-	if err = g.writeErrorHandler(indentLevel); err != nil {
-		return err
+	g.writeStringLiteral(indentLevel, "<!doctype "+n.Value+">")
+	return nil
+}
+
+func (g *generator) writeComment(indentLevel int, n parser.Comment) (err error) {
+	// templ comments are only for the developer, so they're not rendered.
+	if n.Type != parser.CommentTypeHTML {
+		return nil
 	}
+	g.writeStringLiteral(indentLevel, "<!--"+n.Contents+"-->")
 	return nil
 }
 
@@ -443,16 +484,14 @@ func (g *generator) writeIfExpression(indentLevel int, n parser.IfExpression) (e
 	if _, err = g.w.WriteIndent(indentLevel, "// If\n"); err != nil {
 		return err
 	}
-	var r parser.Range
 	// if
 	if _, err = g.w.WriteIndent(indentLevel, `if `); err != nil {
 		return err
 	}
 	// x == y {
-	if r, err = g.w.Write(n.Expression.Value); err != nil {
+	if _, err = g.writeExpression(n.Expression); err != nil {
 		return err
 	}
-	g.sourceMap.Add(n.Expression, r)
 	// {
 	if _, err = g.w.Write(` {` + "\n"); err != nil {
 		return err
@@ -460,6 +499,23 @@ func (g *generator) writeIfExpression(indentLevel int, n parser.IfExpression) (e
 	indentLevel++
 	g.writeNodes(indentLevel, n, stripLeadingAndTrailingWhitespace(n.Then))
 	indentLevel--
+	for _, elseIf := range n.ElseIfs {
+		// } else if {
+		if _, err = g.w.WriteIndent(indentLevel, `} else if `); err != nil {
+			return err
+		}
+		// x == y {
+		if _, err = g.writeExpression(elseIf.Expression); err != nil {
+			return err
+		}
+		// {
+		if _, err = g.w.Write(` {` + "\n"); err != nil {
+			return err
+		}
+		indentLevel++
+		g.writeNodes(indentLevel, n, stripLeadingAndTrailingWhitespace(elseIf.Then))
+		indentLevel--
+	}
 	if len(n.Else) > 0 {
 		// } else {
 		if _, err = g.w.WriteIndent(indentLevel, `} else {`+"\n"); err != nil {
@@ -480,16 +536,14 @@ func (g *generator) writeSwitchExpression(indentLevel int, n parser.SwitchExpres
 	if _, err = g.w.WriteIndent(indentLevel, "// Switch\n"); err != nil {
 		return err
 	}
-	var r parser.Range
 	// switch
 	if _, err = g.w.WriteIndent(indentLevel, `switch `); err != nil {
 		return err
 	}
 	// val
-	if r, err = g.w.Write(n.Expression.Value); err != nil {
+	if _, err = g.writeExpression(n.Expression); err != nil {
 		return err
 	}
-	g.sourceMap.Add(n.Expression, r)
 	// {
 	if _, err = g.w.Write(` {` + "\n"); err != nil {
 		return err
@@ -499,10 +553,12 @@ func (g *generator) writeSwitchExpression(indentLevel int, n parser.SwitchExpres
 		for _, c := range n.Cases {
 			// case x:
 			// default:
-			if r, err = g.w.WriteIndent(indentLevel, c.Expression.Value); err != nil {
+			if _, err = g.w.WriteIndent(indentLevel, ""); err != nil {
+				return err
+			}
+			if _, err = g.writeExpression(c.Expression); err != nil {
 				return err
 			}
-			g.sourceMap.Add(c.Expression, r)
 			indentLevel++
 			g.writeNodes(indentLevel, n, stripLeadingAndTrailingWhitespace(c.Children))
 			indentLevel--
@@ -539,7 +595,6 @@ func (g *generator) writeTemplElementExpression(indentLevel int, n parser.TemplE
 }
 
 func (g *generator) writeBlockTemplElementExpression(indentLevel int, n parser.TemplElementExpression) (err error) {
-	var r parser.Range
 	childrenName := g.createVariableName()
 	if _, err = g.w.WriteIndent(indentLevel, childrenName+" := templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {\n"); err != nil {
 		return err
@@ -557,10 +612,9 @@ func (g *generator) writeBlockTemplElementExpression(indentLevel int, n parser.T
 	if _, err = g.w.WriteIndent(indentLevel, `err = `); err != nil {
 		return err
 	}
-	if r, err = g.w.Write(n.Expression.Value); err != nil {
+	if _, err = g.writeExpression(n.Expression); err != nil {
 		return err
 	}
-	g.sourceMap.Add(n.Expression, r)
 	// .Render(templ.WithChildren(ctx, children), w)
 	if _, err = g.w.Write(".Render(templ.WithChildren(ctx, " + childrenName + "), w)\n"); err != nil {
 		return err
@@ -572,15 +626,13 @@ func (g *generator) writeBlockTemplElementExpression(indentLevel int, n parser.T
 }
 
 func (g *generator) writeSelfClosingTemplElementExpression(indentLevel int, n parser.TemplElementExpression) (err error) {
-	var r parser.Range
-	if r, err = g.w.WriteIndent(indentLevel, `err = `); err != nil {
+	if _, err = g.w.WriteIndent(indentLevel, `err = `); err != nil {
 		return err
 	}
 	// Template expression.
-	if r, err = g.w.Write(n.Expression.Value); err != nil {
+	if _, err = g.writeExpression(n.Expression); err != nil {
 		return err
 	}
-	g.sourceMap.Add(n.Expression, r)
 	// .Render(ctx w)
 	if _, err = g.w.Write(".Render(ctx, w)\n"); err != nil {
 		return err
@@ -595,15 +647,13 @@ func (g *generator) writeCallTemplateExpression(indentLevel int, n parser.CallTe
 	if _, err = g.w.WriteIndent(indentLevel, "// CallTemplate\n"); err != nil {
 		return err
 	}
-	var r parser.Range
-	if r, err = g.w.WriteIndent(indentLevel, `err = `); err != nil {
+	if _, err = g.w.WriteIndent(indentLevel, `err = `); err != nil {
 		return err
 	}
 	// Template expression.
-	if r, err = g.w.Write(n.Expression.Value); err != nil {
+	if _, err = g.writeExpression(n.Expression); err != nil {
 		return err
 	}
-	g.sourceMap.Add(n.Expression, r)
 	// .Render(ctx w)
 	if _, err = g.w.Write(".Render(ctx, w)\n"); err != nil {
 		return err
@@ -618,16 +668,14 @@ func (g *generator) writeForExpression(indentLevel int, n parser.ForExpression)
 	if _, err = g.w.WriteIndent(indentLevel, "// For\n"); err != nil {
 		return err
 	}
-	var r parser.Range
 	// for
 	if _, err = g.w.WriteIndent(indentLevel, `for `); err != nil {
 		return err
 	}
 	// i, v := range p.Stuff
-	if r, err = g.w.Write(n.Expression.Value); err != nil {
+	if _, err = g.writeExpression(n.Expression); err != nil {
 		return err
 	}
-	g.sourceMap.Add(n.Expression, r)
 	// {
 	if _, err = g.w.Write(` {` + "\n"); err != nil {
 		return err
@@ -643,6 +691,32 @@ func (g *generator) writeForExpression(indentLevel int, n parser.ForExpression)
 	return nil
 }
 
+// writeStringLiteral adds constant output to be written. Adjacent constant output, e.g. elements, constant
+// attributes and text, is coalesced into a single io.WriteString call, which is written by flushStringLiterals
+// before any other code.
+func (g *generator) writeStringLiteral(indentLevel int, s string) {
+	if g.stringLiterals.Len() == 0 {
+		g.stringLiteralsIndentLevel = indentLevel
+	}
+	g.stringLiterals.WriteString(s)
+}
+
+func (g *generator) flushStringLiterals() (err error) {
+	if g.stringLiterals.Len() == 0 {
+		return nil
+	}
+	s := g.stringLiterals.String()
+	g.stringLiterals.Reset()
+	// _, err = io.WriteString(w, `<div>Text</div>`)
+	if _, err = g.w.WriteIndent(g.stringLiteralsIndentLevel, "_, err = io.WriteString(w, "+createGoString(s)+")\n"); err != nil {
+		return err
+	}
+	if err = g.writeErrorHandler(g.stringLiteralsIndentLevel); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (g *generator) writeErrorHandler(indentLevel int) (err error) {
 	_, err = g.w.WriteIndent(indentLevel, "if err != nil {\n")
 	if err != nil {
@@ -662,61 +736,87 @@ func (g *generator) writeErrorHandler(indentLevel int) (err error) {
 }
 
 func (g *generator) writeElement(indentLevel int, n parser.Element) (err error) {
+	// The attributes are rewritten to use the variables created for them, so they're copied to leave
+	// the template unchanged.
+	n.Attributes = copyAttributes(n.Attributes)
+	if err = g.writeElementConditions(indentLevel, n.Attributes); err != nil {
+		return err
+	}
 	if n.IsVoidElement() {
 		return g.writeVoidElement(indentLevel, n)
 	}
 	return g.writeStandardElement(indentLevel, n)
 }
 
-func (g *generator) writeVoidElement(indentLevel int, n parser.Element) (err error) {
-	if _, err = g.w.WriteIndent(indentLevel, "// Element (void)\n"); err != nil {
-		return err
-	}
-	if len(n.Children) > 0 {
-		return fmt.Errorf("writeVoidElement: void element %q must not have child elements", n.Name)
+func copyAttributes(attrs []parser.Attribute) []parser.Attribute {
+	copied := make([]parser.Attribute, len(attrs))
+	for i, attr := range attrs {
+		if ca, ok := attr.(parser.ConditionalAttribute); ok {
+			ca.Then = copyAttributes(ca.Then)
+			ca.Else = copyAttributes(ca.Else)
+			attr = ca
+		}
+		copied[i] = attr
 	}
-	if len(n.Attributes) == 0 {
-		// <br>
-		if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, "<%s>")`+"\n", html.EscapeString(n.Name))); err != nil {
-			return err
+	return copied
+}
+
+// writeElementConditions evaluates the condition of each conditional attribute once, since the condition
+// is used to render the CSS and scripts before the element, as well as the attributes. The attributes are
+// rewritten to use the results.
+func (g *generator) writeElementConditions(indentLevel int, attrs []parser.Attribute) (err error) {
+	for i := 0; i < len(attrs); i++ {
+		attr, ok := attrs[i].(parser.ConditionalAttribute)
+		if !ok {
+			continue
 		}
-		if err = g.writeErrorHandler(indentLevel); err != nil {
+		// var templ_7745c5c3_Var1 bool =
+		conditionName := g.createVariableName()
+		if _, err = g.w.WriteIndent(indentLevel, "var "+conditionName+" bool = "); err != nil {
 			return err
 		}
-	} else {
-		// <hr
-		if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, "<%s")`+"\n", html.EscapeString(n.Name))); err != nil {
+		// x == y
+		if _, err = g.writeExpression(attr.Expression); err != nil {
 			return err
 		}
-		if err = g.writeErrorHandler(indentLevel); err != nil {
+		if _, err = g.w.Write("\n"); err != nil {
 			return err
 		}
-		if err = g.writeElementAttributes(indentLevel, n.Name, n.Attributes); err != nil {
-			return err
+		attr.Expression = parser.Expression{
+			Value: conditionName,
 		}
-		// >
-		if _, err = g.w.WriteIndent(indentLevel, `_, err = io.WriteString(w, ">")`+"\n"); err != nil {
+		attrs[i] = attr
+	}
+	return nil
+}
+
+func (g *generator) writeVoidElement(indentLevel int, n parser.Element) (err error) {
+	if len(n.Children) > 0 {
+		return fmt.Errorf("writeVoidElement: void element %q must not have child elements", n.Name)
+	}
+	if len(n.Attributes) == 0 {
+		// <br>
+		g.writeStringLiteral(indentLevel, "<"+html.EscapeString(n.Name)+">")
+	} else {
+		// <input onClick={ ... }/>
+		if err = g.writeElementScript(indentLevel, n); err != nil {
 			return err
 		}
-		if err = g.writeErrorHandler(indentLevel); err != nil {
+		// <hr
+		g.writeStringLiteral(indentLevel, "<"+html.EscapeString(n.Name))
+		if err = g.writeElementAttributes(indentLevel, n.Name, n.Attributes); err != nil {
 			return err
 		}
+		// >
+		g.writeStringLiteral(indentLevel, ">")
 	}
 	return err
 }
 
 func (g *generator) writeStandardElement(indentLevel int, n parser.Element) (err error) {
-	if _, err = g.w.WriteIndent(indentLevel, "// Element (standard)\n"); err != nil {
-		return err
-	}
 	if len(n.Attributes) == 0 {
 		// <div>
-		if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, "<%s>")`+"\n", html.EscapeString(n.Name))); err != nil {
-			return err
-		}
-		if err = g.writeErrorHandler(indentLevel); err != nil {
-			return err
-		}
+		g.writeStringLiteral(indentLevel, "<"+html.EscapeString(n.Name)+">")
 	} else {
 		// <style type="text/css"></style>
 		if err = g.writeElementCSS(indentLevel, n); err != nil {
@@ -727,41 +827,25 @@ func (g *generator) writeStandardElement(indentLevel int, n parser.Element) (err
 			return err
 		}
 		// <div
-		if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, "<%s")`+"\n", html.EscapeString(n.Name))); err != nil {
-			return err
-		}
-		if err = g.writeErrorHandler(indentLevel); err != nil {
-			return err
-		}
+		g.writeStringLiteral(indentLevel, "<"+html.EscapeString(n.Name))
 		if err = g.writeElementAttributes(indentLevel, n.Name, n.Attributes); err != nil {
 			return err
 		}
 		// >
-		if _, err = g.w.WriteIndent(indentLevel, `_, err = io.WriteString(w, ">")`+"\n"); err != nil {
-			return err
-		}
-		if err = g.writeErrorHandler(indentLevel); err != nil {
-			return err
-		}
+		g.writeStringLiteral(indentLevel, ">")
 	}
 	// Children.
 	g.writeNodes(indentLevel, n, stripNonCriticalElementWhitespace(n.Children))
 	// </div>
-	if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, "</%s>")`+"\n", html.EscapeString(n.Name))); err != nil {
-		return err
-	}
-	if err = g.writeErrorHandler(indentLevel); err != nil {
-		return err
-	}
+	g.writeStringLiteral(indentLevel, "</"+html.EscapeString(n.Name)+">")
 	return err
 }
 
 func (g *generator) writeElementCSS(indentLevel int, n parser.Element) (err error) {
-	var r parser.Range
 	for i := 0; i < len(n.Attributes); i++ {
-		if attr, ok := n.Attributes[i].(parser.ExpressionAttribute); ok {
-			name := html.EscapeString(attr.Name)
-			if name != "class" {
+		switch attr := n.Attributes[i].(type) {
+		case parser.ExpressionAttribute:
+			if !isClassExpressionAttribute(attr) {
 				continue
 			}
 			if _, err = g.w.WriteIndent(indentLevel, "// Element CSS\n"); err != nil {
@@ -770,45 +854,157 @@ func (g *generator) writeElementCSS(indentLevel int, n parser.Element) (err erro
 			// Create a class name for the style.
 			// var templCSSClassess templ.CSSClasses =
 			classesName := g.createVariableName()
-			if _, err = g.w.WriteIndent(indentLevel, "var "+classesName+" templ.CSSClasses = "); err != nil {
-				return err
-			}
-			// p.Name()
-			if r, err = g.w.Write(attr.Expression.Value); err != nil {
+			if n.Attributes[i], err = g.writeClassesCSS(indentLevel, "var "+classesName+" templ.CSSClasses = ", classesName, attr); err != nil {
 				return err
 			}
-			g.sourceMap.Add(attr.Expression, r)
-			if _, err = g.w.Write("\n"); err != nil {
-				return err
-			}
-			// Render the CSS before the element if required.
-			// err = templ.RenderCSS(ctx, w, templCSSClassess)
-			if _, err = g.w.WriteIndent(indentLevel, "err = templ.RenderCSS(ctx, w, "+classesName+")\n"); err != nil {
-				return err
-			}
-			if err = g.writeErrorHandler(indentLevel); err != nil {
+		case parser.ConditionalAttribute:
+			if err = g.writeConditionalAttributeCSS(indentLevel, attr); err != nil {
 				return err
 			}
-			// Rewrite the ExpressionAttribute to point at the new variable.
-			attr.Expression = parser.Expression{
-				Value: classesName + ".String()",
-			}
-			n.Attributes[i] = attr
 		}
 	}
 	return err
 }
 
+func isClassExpressionAttribute(attr parser.Attribute) bool {
+	ea, ok := attr.(parser.ExpressionAttribute)
+	return ok && html.EscapeString(ea.Name) == "class"
+}
+
+// writeClassesCSS assigns the class attribute's expression to a variable, renders the CSS, and returns
+// the attribute rewritten to use the variable.
+func (g *generator) writeClassesCSS(indentLevel int, assignment, classesName string, attr parser.ExpressionAttribute) (parser.ExpressionAttribute, error) {
+	var err error
+	if _, err = g.w.WriteIndent(indentLevel, assignment); err != nil {
+		return attr, err
+	}
+	// p.Name()
+	if _, err = g.writeExpression(attr.Expression); err != nil {
+		return attr, err
+	}
+	if _, err = g.w.Write("\n"); err != nil {
+		return attr, err
+	}
+	// Render the CSS before the element if required.
+	// err = templ.RenderCSS(ctx, w, templCSSClassess)
+	if _, err = g.w.WriteIndent(indentLevel, "err = templ.RenderCSS(ctx, w, "+classesName+")\n"); err != nil {
+		return attr, err
+	}
+	if err = g.writeErrorHandler(indentLevel); err != nil {
+		return attr, err
+	}
+	// Rewrite the ExpressionAttribute to point at the new variable.
+	attr.Expression = parser.Expression{
+		Value: classesName + ".String()",
+	}
+	return attr, nil
+}
+
+func (g *generator) writeConditionalAttributeCSS(indentLevel int, attr parser.ConditionalAttribute) (err error) {
+	thenClassesNames, elseClassesNames := map[int]string{}, map[int]string{}
+	for i, a := range attr.Then {
+		if isClassExpressionAttribute(a) {
+			thenClassesNames[i] = g.createVariableName()
+		}
+	}
+	for i, a := range attr.Else {
+		if isClassExpressionAttribute(a) {
+			elseClassesNames[i] = g.createVariableName()
+		}
+	}
+	if len(thenClassesNames) == 0 && len(elseClassesNames) == 0 {
+		return nil
+	}
+	if _, err = g.w.WriteIndent(indentLevel, "// Element CSS (conditional)\n"); err != nil {
+		return err
+	}
+	// The variables are declared outside of the if statement, so that they're still in scope
+	// when the attributes are written.
+	// var templCSSClassess templ.CSSClasses
+	for _, attrs := range []struct {
+		attrs []parser.Attribute
+		names map[int]string
+	}{{attr.Then, thenClassesNames}, {attr.Else, elseClassesNames}} {
+		for i := range attrs.attrs {
+			if name, ok := attrs.names[i]; ok {
+				if _, err = g.w.WriteIndent(indentLevel, "var "+name+" templ.CSSClasses\n"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	// if
+	if _, err = g.w.WriteIndent(indentLevel, `if `); err != nil {
+		return err
+	}
+	// x == y
+	if _, err = g.writeExpression(attr.Expression); err != nil {
+		return err
+	}
+	// {
+	if _, err = g.w.Write(` {` + "\n"); err != nil {
+		return err
+	}
+	if err = g.writeConditionalClassesCSS(indentLevel+1, attr.Then, thenClassesNames); err != nil {
+		return err
+	}
+	if len(elseClassesNames) > 0 {
+		// } else {
+		if _, err = g.w.WriteIndent(indentLevel, `} else {`+"\n"); err != nil {
+			return err
+		}
+		if err = g.writeConditionalClassesCSS(indentLevel+1, attr.Else, elseClassesNames); err != nil {
+			return err
+		}
+	}
+	// }
+	if _, err = g.w.WriteIndent(indentLevel, `}`+"\n"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (g *generator) writeConditionalClassesCSS(indentLevel int, attrs []parser.Attribute, classesNames map[int]string) (err error) {
+	for i := 0; i < len(attrs); i++ {
+		classesName, ok := classesNames[i]
+		if !ok {
+			continue
+		}
+		// templCSSClassess =
+		if attrs[i], err = g.writeClassesCSS(indentLevel, classesName+" = ", classesName, attrs[i].(parser.ExpressionAttribute)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (g *generator) writeElementScript(indentLevel int, n parser.Element) (err error) {
-	var scriptExpressions []string
+	if err = g.writeAttributesScript(indentLevel, n.Attributes); err != nil {
+		return err
+	}
 	for i := 0; i < len(n.Attributes); i++ {
-		if attr, ok := n.Attributes[i].(parser.ExpressionAttribute); ok {
-			name := html.EscapeString(attr.Name)
-			if strings.HasPrefix(name, "on") {
+		if attr, ok := n.Attributes[i].(parser.ConditionalAttribute); ok {
+			if err = g.writeConditionalAttributeScript(indentLevel, attr); err != nil {
+				return err
+			}
+		}
+	}
+	return g.writeElementSpreadAttributesScript(indentLevel, n)
+}
+
+func scriptExpressions(attrs []parser.Attribute) (scriptExpressions []string) {
+	for i := 0; i < len(attrs); i++ {
+		if attr, ok := attrs[i].(parser.ExpressionAttribute); ok {
+			if templ.AttributeContextOf(attr.Name) == templ.AttributeContextJS {
 				scriptExpressions = append(scriptExpressions, attr.Expression.Value)
 			}
 		}
 	}
+	return scriptExpressions
+}
+
+func (g *generator) writeAttributesScript(indentLevel int, attrs []parser.Attribute) (err error) {
+	scriptExpressions := scriptExpressions(attrs)
 	if len(scriptExpressions) == 0 {
 		return
 	}
@@ -826,193 +1022,273 @@ func (g *generator) writeElementScript(indentLevel int, n parser.Element) (err e
 	return err
 }
 
-func (g *generator) writeElementAttributes(indentLevel int, name string, attrs []parser.Attribute) (err error) {
-	if _, err = g.w.WriteIndent(indentLevel, "// Element Attributes\n"); err != nil {
+func (g *generator) writeConditionalAttributeScript(indentLevel int, attr parser.ConditionalAttribute) (err error) {
+	hasElseScripts := len(scriptExpressions(attr.Else)) > 0
+	if len(scriptExpressions(attr.Then)) == 0 && !hasElseScripts {
+		return nil
+	}
+	// if
+	if _, err = g.w.WriteIndent(indentLevel, `if `); err != nil {
 		return err
 	}
-	var r parser.Range
+	// x == y
+	if _, err = g.writeExpression(attr.Expression); err != nil {
+		return err
+	}
+	// {
+	if _, err = g.w.Write(` {` + "\n"); err != nil {
+		return err
+	}
+	if err = g.writeAttributesScript(indentLevel+1, attr.Then); err != nil {
+		return err
+	}
+	if hasElseScripts {
+		// } else {
+		if _, err = g.w.WriteIndent(indentLevel, `} else {`+"\n"); err != nil {
+			return err
+		}
+		if err = g.writeAttributesScript(indentLevel+1, attr.Else); err != nil {
+			return err
+		}
+	}
+	// }
+	if _, err = g.w.WriteIndent(indentLevel, `}`+"\n"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (g *generator) writeElementSpreadAttributesScript(indentLevel int, n parser.Element) (err error) {
+	for i := 0; i < len(n.Attributes); i++ {
+		attr, ok := n.Attributes[i].(parser.SpreadAttributes)
+		if !ok {
+			continue
+		}
+		if _, err = g.w.WriteIndent(indentLevel, "// Element Script (spread attributes)\n"); err != nil {
+			return err
+		}
+		// The attributes may contain on* handlers, so they're evaluated once, before the element.
+		// var templAttributes templ.Attributes =
+		attributesName := g.createVariableName()
+		if _, err = g.w.WriteIndent(indentLevel, "var "+attributesName+" templ.Attributes = "); err != nil {
+			return err
+		}
+		// p.Attributes()
+		if _, err = g.writeExpression(attr.Expression); err != nil {
+			return err
+		}
+		if _, err = g.w.Write("\n"); err != nil {
+			return err
+		}
+		// err = templ.RenderScripts(ctx, w, templAttributes.Scripts()...)
+		if _, err = g.w.WriteIndent(indentLevel, "err = templ.RenderScripts(ctx, w, "+attributesName+".Scripts()...)\n"); err != nil {
+			return err
+		}
+		if err = g.writeErrorHandler(indentLevel); err != nil {
+			return err
+		}
+		// Rewrite the SpreadAttributes to point at the new variable.
+		attr.Expression = parser.Expression{
+			Value: attributesName,
+		}
+		n.Attributes[i] = attr
+	}
+	return err
+}
+
+func (g *generator) writeElementAttributes(indentLevel int, name string, attrs []parser.Attribute) (err error) {
 	for i := 0; i < len(attrs); i++ {
-		switch attr := attrs[i].(type) {
-		case parser.BoolConstantAttribute:
-			name := html.EscapeString(attr.Name)
-			if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, " %s")`+"\n", name)); err != nil {
+		if err = g.writeElementAttribute(indentLevel, name, attrs[i]); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (g *generator) writeElementAttribute(indentLevel int, name string, attr parser.Attribute) (err error) {
+	switch attr := attr.(type) {
+	case parser.BoolConstantAttribute:
+		name := html.EscapeString(attr.Name)
+		g.writeStringLiteral(indentLevel, " "+name)
+	case parser.ConstantAttribute:
+		name := html.EscapeString(attr.Name)
+		value := html.EscapeString(attr.Value)
+		g.writeStringLiteral(indentLevel, " "+name+"=\""+value+"\"")
+	case parser.BoolExpressionAttribute:
+		name := html.EscapeString(attr.Name)
+		// if
+		if _, err = g.w.WriteIndent(indentLevel, `if `); err != nil {
+			return err
+		}
+		// x == y
+		if _, err = g.writeExpression(attr.Expression); err != nil {
+			return err
+		}
+		// {
+		if _, err = g.w.Write(` {` + "\n"); err != nil {
+			return err
+		}
+		{
+			indentLevel++
+			g.writeStringLiteral(indentLevel, " "+name)
+			indentLevel--
+		}
+		// }
+		if _, err = g.w.WriteIndent(indentLevel, `}`+"\n"); err != nil {
+			return err
+		}
+	case parser.ExpressionAttribute:
+		attrName := html.EscapeString(attr.Name)
+		// Name
+		g.writeStringLiteral(indentLevel, " "+attrName+"=")
+		// Value.
+		// Open quote.
+		g.writeStringLiteral(indentLevel, "\"")
+		switch templ.AttributeContextOf(attr.Name) {
+		case templ.AttributeContextURL:
+			// URLs are sanitized, unless they're a templ.SafeURL.
+			if err = g.writeSanitizedAttributeValue(indentLevel, "templ.SanitizeURL", attr.Expression); err != nil {
 				return err
 			}
-			if err = g.writeErrorHandler(indentLevel); err != nil {
+		case templ.AttributeContextSrcset:
+			// Each URL in a srcset is sanitized, unless it's a templ.SafeURL.
+			if err = g.writeSanitizedAttributeValue(indentLevel, "templ.SanitizeSrcset", attr.Expression); err != nil {
 				return err
 			}
-		case parser.ConstantAttribute:
-			name := html.EscapeString(attr.Name)
-			value := html.EscapeString(attr.Value)
-			if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, " %s=\"%s\"")`+"\n", name, value)); err != nil {
+		case templ.AttributeContextJS:
+			// It's a JavaScript handler, and requires special handling, because we expect a JavaScript expression.
+			vn := g.createVariableName()
+			// var vn templ.ComponentScript =
+			if _, err = g.w.WriteIndent(indentLevel, "var "+vn+" templ.ComponentScript = "); err != nil {
 				return err
 			}
-			if err = g.writeErrorHandler(indentLevel); err != nil {
+			// p.Name()
+			if _, err = g.writeExpression(attr.Expression); err != nil {
 				return err
 			}
-		case parser.BoolExpressionAttribute:
-			name := html.EscapeString(attr.Name)
-			// if
-			if _, err = g.w.WriteIndent(indentLevel, `if `); err != nil {
+			if _, err = g.w.Write("\n"); err != nil {
 				return err
 			}
-			// x == y
-			if r, err = g.w.Write(attr.Expression.Value); err != nil {
+			if _, err = g.w.WriteIndent(indentLevel, "_, err = io.WriteString(w, "+vn+".Call)\n"); err != nil {
 				return err
 			}
-			g.sourceMap.Add(attr.Expression, r)
-			// {
-			if _, err = g.w.Write(` {` + "\n"); err != nil {
+			if err = g.writeErrorHandler(indentLevel); err != nil {
 				return err
 			}
-			{
-				indentLevel++
-				if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, " %s")`+"\n", name)); err != nil {
-					return err
-				}
-				if err = g.writeErrorHandler(indentLevel); err != nil {
-					return err
-				}
-				indentLevel--
+		default:
+			// io.WriteString(w, templ.EscapeString(
+			if _, err = g.w.WriteIndent(indentLevel, "_, err = io.WriteString(w, templ.EscapeString("); err != nil {
+				return err
 			}
-			// }
-			if _, err = g.w.WriteIndent(indentLevel, `}`+"\n"); err != nil {
+			// p.Name()
+			if _, err = g.writeExpression(attr.Expression); err != nil {
 				return err
 			}
-		case parser.ExpressionAttribute:
-			attrName := html.EscapeString(attr.Name)
-			// Name
-			if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, " %s=")`+"\n", attrName)); err != nil {
+			// ))
+			if _, err = g.w.Write("))\n"); err != nil {
 				return err
 			}
 			if err = g.writeErrorHandler(indentLevel); err != nil {
 				return err
 			}
-			// Value.
-			// Open quote.
-			if _, err = g.w.WriteIndent(indentLevel, `_, err = io.WriteString(w, "\"")`+"\n"); err != nil {
+		}
+		// Close quote.
+		g.writeStringLiteral(indentLevel, "\"")
+	case parser.SpreadAttributes:
+		// err = templ.RenderAttributes(ctx, w,
+		if _, err = g.w.WriteIndent(indentLevel, "err = templ.RenderAttributes(ctx, w, "); err != nil {
+			return err
+		}
+		// p.Attributes()
+		if _, err = g.writeExpression(attr.Expression); err != nil {
+			return err
+		}
+		// )
+		if _, err = g.w.Write(")\n"); err != nil {
+			return err
+		}
+		if err = g.writeErrorHandler(indentLevel); err != nil {
+			return err
+		}
+	case parser.ConditionalAttribute:
+		// if
+		if _, err = g.w.WriteIndent(indentLevel, `if `); err != nil {
+			return err
+		}
+		// x == y
+		if _, err = g.writeExpression(attr.Expression); err != nil {
+			return err
+		}
+		// {
+		if _, err = g.w.Write(` {` + "\n"); err != nil {
+			return err
+		}
+		for _, a := range attr.Then {
+			if err = g.writeElementAttribute(indentLevel+1, name, a); err != nil {
 				return err
 			}
-			if err = g.writeErrorHandler(indentLevel); err != nil {
+		}
+		if len(attr.Else) > 0 {
+			// } else {
+			if _, err = g.w.WriteIndent(indentLevel, `} else {`+"\n"); err != nil {
 				return err
 			}
-			if name == "a" && attr.Name == "href" {
-				vn := g.createVariableName()
-				// var vn templ.SafeURL =
-				if _, err = g.w.WriteIndent(indentLevel, "var "+vn+" templ.SafeURL = "); err != nil {
-					return err
-				}
-				// p.Name()
-				if r, err = g.w.Write(attr.Expression.Value); err != nil {
-					return err
-				}
-				g.sourceMap.Add(attr.Expression, r)
-				if _, err = g.w.Write("\n"); err != nil {
-					return err
-				}
-				if _, err = g.w.WriteIndent(indentLevel, "_, err = io.WriteString(w, templ.EscapeString(string("+vn+")))\n"); err != nil {
+			for _, a := range attr.Else {
+				if err = g.writeElementAttribute(indentLevel+1, name, a); err != nil {
 					return err
 				}
-				if err = g.writeErrorHandler(indentLevel); err != nil {
-					return err
-				}
-			} else {
-				if strings.HasPrefix(attr.Name, "on") {
-					// It's a JavaScript handler, and requires special handling, because we expect a JavaScript expression.
-					vn := g.createVariableName()
-					// var vn templ.ComponentScript =
-					if _, err = g.w.WriteIndent(indentLevel, "var "+vn+" templ.ComponentScript = "); err != nil {
-						return err
-					}
-					// p.Name()
-					if r, err = g.w.Write(attr.Expression.Value); err != nil {
-						return err
-					}
-					g.sourceMap.Add(attr.Expression, r)
-					if _, err = g.w.Write("\n"); err != nil {
-						return err
-					}
-					if _, err = g.w.WriteIndent(indentLevel, "_, err = io.WriteString(w, "+vn+".Call)\n"); err != nil {
-						return err
-					}
-					if err = g.writeErrorHandler(indentLevel); err != nil {
-						return err
-					}
-				} else {
-					// io.WriteString(w, templ.EscapeString(
-					if _, err = g.w.WriteIndent(indentLevel, "_, err = io.WriteString(w, templ.EscapeString("); err != nil {
-						return err
-					}
-					// p.Name()
-					if r, err = g.w.Write(attr.Expression.Value); err != nil {
-						return err
-					}
-					g.sourceMap.Add(attr.Expression, r)
-					// ))
-					if _, err = g.w.Write("))\n"); err != nil {
-						return err
-					}
-					if err = g.writeErrorHandler(indentLevel); err != nil {
-						return err
-					}
-				}
-			}
-			// Close quote.
-			if _, err = g.w.WriteIndent(indentLevel, `_, err = io.WriteString(w, "\"")`+"\n"); err != nil {
-				return err
-			}
-			if err = g.writeErrorHandler(indentLevel); err != nil {
-				return err
 			}
-		default:
-			return fmt.Errorf("unknown attribute type %s", reflect.TypeOf(attrs[i]))
 		}
+		// }
+		if _, err = g.w.WriteIndent(indentLevel, `}`+"\n"); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown attribute type %s", reflect.TypeOf(attr))
 	}
 	return err
 }
 
-func (g *generator) writeRawElement(indentLevel int, n parser.RawElement) (err error) {
-	if _, err = g.w.WriteIndent(0, "// RawElement\n"); err != nil {
+func (g *generator) writeSanitizedAttributeValue(indentLevel int, sanitizer string, expression parser.Expression) (err error) {
+	vn := g.createVariableName()
+	// var vn templ.SafeURL = templ.SanitizeURL(
+	if _, err = g.w.WriteIndent(indentLevel, "var "+vn+" templ.SafeURL = "+sanitizer+"("); err != nil {
+		return err
+	}
+	// p.Name()
+	if _, err = g.writeExpression(expression); err != nil {
+		return err
+	}
+	// )
+	if _, err = g.w.Write(")\n"); err != nil {
+		return err
+	}
+	if _, err = g.w.WriteIndent(indentLevel, "_, err = io.WriteString(w, templ.EscapeString(string("+vn+")))\n"); err != nil {
+		return err
+	}
+	if err = g.writeErrorHandler(indentLevel); err != nil {
 		return err
 	}
+	return nil
+}
+
+func (g *generator) writeRawElement(indentLevel int, n parser.RawElement) (err error) {
 	if len(n.Attributes) == 0 {
 		// <div>
-		if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, "<%s>")`+"\n", html.EscapeString(n.Name))); err != nil {
-			return err
-		}
-		if err = g.writeErrorHandler(indentLevel); err != nil {
-			return err
-		}
+		g.writeStringLiteral(indentLevel, "<"+html.EscapeString(n.Name)+">")
 	} else {
 		// <div
-		if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, "<%s")`+"\n", html.EscapeString(n.Name))); err != nil {
-			return err
-		}
-		if err = g.writeErrorHandler(indentLevel); err != nil {
-			return err
-		}
+		g.writeStringLiteral(indentLevel, "<"+html.EscapeString(n.Name))
 		if err = g.writeElementAttributes(indentLevel, n.Name, n.Attributes); err != nil {
 			return err
 		}
 		// >
-		if _, err = g.w.WriteIndent(indentLevel, `_, err = io.WriteString(w, ">")`+"\n"); err != nil {
-			return err
-		}
-		if err = g.writeErrorHandler(indentLevel); err != nil {
-			return err
-		}
+		g.writeStringLiteral(indentLevel, ">")
 	}
 	// Contents.
-	g.writeText(0, parser.Text{
-		Value: n.Contents,
-	})
+	g.writeStringLiteral(indentLevel, n.Contents)
 	// </div>
-	if _, err = g.w.WriteIndent(indentLevel, fmt.Sprintf(`_, err = io.WriteString(w, "</%s>")`+"\n", html.EscapeString(n.Name))); err != nil {
-		return err
-	}
-	if err = g.writeErrorHandler(indentLevel); err != nil {
-		return err
-	}
+	g.writeStringLiteral(indentLevel, "</"+html.EscapeString(n.Name)+">")
 	return err
 }
 
@@ -1025,16 +1301,14 @@ func (g *generator) writeStringExpression(indentLevel int, e parser.Expression)
 	if _, err = g.w.WriteIndent(indentLevel, "// StringExpression\n"); err != nil {
 		return err
 	}
-	var r parser.Range
 	// io.WriteString(w, templ.EscapeString(
 	if _, err = g.w.WriteIndent(indentLevel, "_, err = io.WriteString(w, templ.EscapeString("); err != nil {
 		return err
 	}
 	// p.Name()
-	if r, err = g.w.Write(e.Value); err != nil {
+	if _, err = g.writeExpression(e); err != nil {
 		return err
 	}
-	g.sourceMap.Add(e, r)
 	// ))
 	if _, err = g.w.Write("))\n"); err != nil {
 		return err
@@ -1049,39 +1323,21 @@ func (g *generator) writeWhitespace(indentLevel int, n parser.Whitespace) (err e
 	if len(n.Value) == 0 {
 		return
 	}
-	if _, err = g.w.WriteIndent(indentLevel, "// Whitespace (normalised)\n"); err != nil {
-		return err
-	}
-	// _, err = io.WriteString(w, ` `)
-	if _, err = g.w.WriteIndent(indentLevel, "_, err = io.WriteString(w, ` `)\n"); err != nil {
-		return err
-	}
-	if err = g.writeErrorHandler(indentLevel); err != nil {
-		return err
-	}
+	// Whitespace is normalised to a single space.
+	g.writeStringLiteral(indentLevel, " ")
 	return nil
 }
 
 func (g *generator) writeText(indentLevel int, n parser.Text) (err error) {
-	if _, err = g.w.WriteIndent(indentLevel, "// Text\n"); err != nil {
-		return err
-	}
-	vn := g.createVariableName()
-	// vn := sExpr
-	if _, err = g.w.WriteIndent(indentLevel, vn+" := "+createGoString(n.Value)+"\n"); err != nil {
-		return err
-	}
-	// _, err = io.WriteString(w, vn)
-	if _, err = g.w.WriteIndent(indentLevel, "_, err = io.WriteString(w, "+vn+")\n"); err != nil {
-		return err
-	}
-	if err = g.writeErrorHandler(indentLevel); err != nil {
-		return err
-	}
+	g.writeStringLiteral(indentLevel, n.Value)
 	return nil
 }
 
 func createGoString(s string) string {
+	// Carriage returns are removed from raw string literals.
+	if strings.ContainsRune(s, '\r') {
+		return strconv.Quote(s)
+	}
 	var sb strings.Builder
 	sb.WriteRune('`')
 	sects := strings.Split(s, "`")
@@ -1096,7 +1352,6 @@ func createGoString(s string) string {
 }
 
 func (g *generator) writeScript(t parser.ScriptTemplate) error {
-	var r parser.Range
 	var err error
 	var indentLevel int
 
@@ -1104,19 +1359,17 @@ func (g *generator) writeScript(t parser.ScriptTemplate) error {
 	if _, err = g.w.Write("func "); err != nil {
 		return err
 	}
-	if r, err = g.w.Write(t.Name.Value); err != nil {
+	if _, err = g.writeExpression(t.Name); err != nil {
 		return err
 	}
-	g.sourceMap.Add(t.Name, r)
 	// (
 	if _, err = g.w.Write("("); err != nil {
 		return err
 	}
 	// Write parameters.
-	if r, err = g.w.Write(t.Parameters.Value); err != nil {
+	if _, err = g.writeExpression(t.Parameters); err != nil {
 		return err
 	}
-	g.sourceMap.Add(t.Parameters, r)
 	// ) templ.ComponentScript {
 	if _, err = g.w.Write(") templ.ComponentScript {\n"); err != nil {
 		return err
@@ -2,10 +2,15 @@ package generator
 
 import (
 	"bytes"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"strings"
 	"testing"
 
 	"github.com/a-h/templ/parser/v2"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestGeneratorSourceMap(t *testing.T) {
@@ -37,3 +42,125 @@ func TestGeneratorSourceMap(t *testing.T) {
 		t.Errorf("unexpected target:\n%v", diff)
 	}
 }
+
+func TestGeneratorSourceMapIfExpression(t *testing.T) {
+	w := new(bytes.Buffer)
+	g := generator{
+		w:         NewRangeWriter(w),
+		sourceMap: parser.NewSourceMap(),
+	}
+	exp := parser.IfExpression{
+		Expression: parser.Expression{Value: "a"},
+		ElseIfs: []parser.ElseIfExpression{
+			{Expression: parser.Expression{Value: "b"}},
+			{Expression: parser.Expression{Value: "c"}},
+		},
+	}
+	err := g.writeIfExpression(0, exp)
+	if err != nil {
+		t.Fatalf("failed to write if expression: %v", err)
+	}
+	var actual []string
+	for _, item := range g.sourceMap.Items {
+		actual = append(actual, item.Source.Value)
+	}
+	expected := []string{"a", "b", "c"}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("expected every condition to be in the source map:\n%v", diff)
+	}
+	// Each condition should map to its position in the output.
+	for _, item := range g.sourceMap.Items {
+		if got := w.String()[item.Target.From.Index:item.Target.To.Index]; got != item.Source.Value {
+			t.Errorf("expected target range to contain %q, got %q", item.Source.Value, got)
+		}
+	}
+}
+
+func TestGeneratorLineDirectives(t *testing.T) {
+	template, err := parser.ParseString(`package test
+
+templ Greeting(name string) {
+	<div>{ strings.ToUpper("héllo, " + name) }</div>
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	w := new(bytes.Buffer)
+	_, err = Generate(template, w, WithLineDirectives("template.templ", "template_templ.go"))
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	fset := token.NewFileSet()
+	f, err := goparser.ParseFile(fset, "template_templ.go", w.Bytes(), 0)
+	if err != nil {
+		t.Fatalf("failed to parse generated code: %v\n%s", err, w.String())
+	}
+	var toUpper, escapeStringEnd token.Position
+	var after []token.Position
+	ast.Inspect(f, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "EscapeString" {
+				escapeStringEnd = fset.Position(call.Rparen)
+			}
+		}
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			switch sel.Sel.Name {
+			case "ToUpper":
+				toUpper = fset.Position(sel.Pos())
+			case "WriteString":
+				if toUpper.IsValid() {
+					after = append(after, fset.Position(sel.Sel.Pos()))
+				}
+			}
+		}
+		return true
+	})
+	// The expression should be reported at its position in the templ file.
+	expected := token.Position{Filename: "template.templ", Line: 4, Column: 9}
+	if diff := cmp.Diff(expected, toUpper, cmpopts.IgnoreFields(token.Position{}, "Offset")); diff != "" {
+		t.Errorf("unexpected expression position:\n%v", diff)
+	}
+	// Generated code after the expression should be reported at its actual position in the Go file,
+	// where columns are in bytes.
+	if len(after) == 0 {
+		t.Fatalf("expected generated code after the expression:\n%s", w.String())
+	}
+	lines := strings.Split(w.String(), "\n")
+	if after[0].Filename != "template_templ.go" || after[0].Line < 1 || after[0].Line > len(lines) {
+		t.Fatalf("unexpected generated code position: %v", after[0])
+	}
+	if line := lines[after[0].Line-1]; !strings.HasPrefix(line[after[0].Column-1:], "WriteString") {
+		t.Errorf("expected generated code position %v to point at WriteString, got %q", after[0], line)
+	}
+	// The code immediately after the expression is on the same line as the non-ASCII string.
+	if escapeStringEnd.Filename != "template_templ.go" || escapeStringEnd.Line < 1 || escapeStringEnd.Line > len(lines) {
+		t.Fatalf("unexpected generated code position: %v", escapeStringEnd)
+	}
+	if line := lines[escapeStringEnd.Line-1]; !strings.HasPrefix(line[escapeStringEnd.Column-1:], ")") {
+		t.Errorf("expected generated code position %v to point at the end of the EscapeString call, got %q", escapeStringEnd, line)
+	}
+}
+
+func TestGenerateDoesNotChangeTheTemplate(t *testing.T) {
+	template, err := parser.ParseString(`package test
+
+templ Button(attrs templ.Attributes, classes templ.CSSClasses, active bool) {
+	<button class={ classes } { attrs... } if active { class={ classes } }>Click</button>
+	<input { attrs... }/>
+}
+`)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	generate := func() string {
+		w := new(bytes.Buffer)
+		if _, err := Generate(template, w); err != nil {
+			t.Fatalf("failed to generate: %v", err)
+		}
+		return w.String()
+	}
+	if diff := cmp.Diff(generate(), generate()); diff != "" {
+		t.Errorf("expected generating code twice to give the same result:\n%s", diff)
+	}
+}
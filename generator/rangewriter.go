@@ -16,7 +16,11 @@ func NewRangeWriter(w io.Writer) *RangeWriter {
 
 type RangeWriter struct {
 	Current parser.Position
-	w       io.Writer
+	// lineIndex is the byte index of the start of the current line, since Go columns are in bytes.
+	lineIndex int64
+	w         io.Writer
+	// beforeWrite is called before anything is written.
+	beforeWrite func() error
 }
 
 func (rw *RangeWriter) WriteIndent(level int, s string) (r parser.Range, err error) {
@@ -28,6 +32,11 @@ func (rw *RangeWriter) WriteIndent(level int, s string) (r parser.Range, err err
 }
 
 func (rw *RangeWriter) Write(s string) (r parser.Range, err error) {
+	if rw.beforeWrite != nil {
+		if err = rw.beforeWrite(); err != nil {
+			return
+		}
+	}
 	r.From = parser.Position{
 		Index: rw.Current.Index,
 		Line:  rw.Current.Line,
@@ -45,6 +54,9 @@ func (rw *RangeWriter) Write(s string) (r parser.Range, err error) {
 		if err != nil {
 			return r, err
 		}
+		if c == '\n' {
+			rw.lineIndex = rw.Current.Index
+		}
 	}
 	r.To = rw.Current
 	return r, err
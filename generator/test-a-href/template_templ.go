@@ -14,101 +14,25 @@ func render() templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
-		_, err = io.WriteString(w, "<a")
+		_, err = io.WriteString(w, `<a href="javascript:alert(&#39;unaffected&#39;);">Ignored</a><a href="`)
 		if err != nil {
 			return err
 		}
-		// Element Attributes
-		_, err = io.WriteString(w, " href=\"javascript:alert(&#39;unaffected&#39;);\"")
+		var var_2 templ.SafeURL = templ.SanitizeURL(templ.URL("javascript:alert('should be sanitized')"))
+		_, err = io.WriteString(w, templ.EscapeString(string(var_2)))
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `">Sanitized</a><a href="`)
 		if err != nil {
 			return err
 		}
-		// Text
-		var_2 := `Ignored`
-		_, err = io.WriteString(w, var_2)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</a>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<a")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " href=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		var var_3 templ.SafeURL = templ.URL("javascript:alert('should be sanitized')")
+		var var_3 templ.SafeURL = templ.SanitizeURL(templ.SafeURL("javascript:alert('should not be sanitized')"))
 		_, err = io.WriteString(w, templ.EscapeString(string(var_3)))
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_4 := `Sanitized`
-		_, err = io.WriteString(w, var_4)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</a>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<a")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " href=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		var var_5 templ.SafeURL = templ.SafeURL("javascript:alert('should not be sanitized')")
-		_, err = io.WriteString(w, templ.EscapeString(string(var_5)))
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_6 := `Unsanitized`
-		_, err = io.WriteString(w, var_6)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</a>")
+		_, err = io.WriteString(w, `">Unsanitized</a>`)
 		if err != nil {
 			return err
 		}
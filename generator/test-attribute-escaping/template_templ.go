@@ -14,49 +14,16 @@ func BasicTemplate(url string) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
-		_, err = io.WriteString(w, "<div>")
+		_, err = io.WriteString(w, `<div><a href="`)
 		if err != nil {
 			return err
 		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<a")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " href=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		var var_2 templ.SafeURL = templ.URL(url)
+		var var_2 templ.SafeURL = templ.SanitizeURL(templ.URL(url))
 		_, err = io.WriteString(w, templ.EscapeString(string(var_2)))
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_3 := `text`
-		_, err = io.WriteString(w, var_3)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</a>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</div>")
+		_, err = io.WriteString(w, `">text</a></div>`)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,26 @@
+package testattributenames
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const expected = `<div x-data="{ open: false }" x-cloak>` +
+	`<button @click="open = !open" :class="a &amp; b" x-on:keyup.enter="open = false">Toggle</button>` +
+	`<form hx-post="/submit" hx-on::after-request="this.reset()" x-on:submit.prevent="submit"></form>` +
+	`<p xml:lang="en" _="on click toggle .red"></p>` +
+	`</div>`
+
+func TestHTML(t *testing.T) {
+	w := new(strings.Builder)
+	err := BasicTemplate("a & b").Render(context.Background(), w)
+	if err != nil {
+		t.Errorf("failed to render: %v", err)
+	}
+	if diff := cmp.Diff(expected, w.String()); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,32 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package testattributenames
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+
+func BasicTemplate(classes string) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
+		ctx, _ = templ.RenderedScriptsFromContext(ctx)
+		var_1 := ctx
+		ctx = templ.ClearChildren(var_1)
+		_, err = io.WriteString(w, `<div x-data="{ open: false }" x-cloak><button @click="open = !open" :class="`)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, templ.EscapeString(classes))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `" x-on:keyup.enter="open = false">Toggle</button><form hx-post="/submit" hx-on::after-request="this.reset()" x-on:submit.prevent="submit"></form><p xml:lang="en" _="on click toggle .red"></p></div>`)
+		if err != nil {
+			return err
+		}
+		return err
+	})
+}
+
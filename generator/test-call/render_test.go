@@ -23,3 +23,19 @@ func TestCall(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func BenchmarkCall(b *testing.B) {
+	b.ReportAllocs()
+	p := person{
+		name:  "Luiz Bonfa",
+		email: "luiz@example.com",
+	}
+	w := new(strings.Builder)
+	for i := 0; i < b.N; i++ {
+		err := personTemplate(p).Render(context.Background(), w)
+		if err != nil {
+			b.Errorf("failed to render: %v", err)
+		}
+		w.Reset()
+	}
+}
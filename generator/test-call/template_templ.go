@@ -14,13 +14,7 @@ func personTemplate(p person) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
-		_, err = io.WriteString(w, "<div>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<h1>")
+		_, err = io.WriteString(w, `<div><h1>`)
 		if err != nil {
 			return err
 		}
@@ -29,29 +23,7 @@ func personTemplate(p person) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</h1>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<div")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " style=\"font-family: &#39;sans-serif&#39;\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " id=\"test\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " data-contents=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, `</h1><div style="font-family: &#39;sans-serif&#39;" id="test" data-contents="`)
 		if err != nil {
 			return err
 		}
@@ -59,11 +31,7 @@ func personTemplate(p person) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `">`)
 		if err != nil {
 			return err
 		}
@@ -72,11 +40,7 @@ func personTemplate(p person) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</div>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</div>")
+		_, err = io.WriteString(w, `</div></div>`)
 		if err != nil {
 			return err
 		}
@@ -90,41 +54,16 @@ func email(s string) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_2 := ctx
 		ctx = templ.ClearChildren(var_2)
-		// Element (standard)
-		_, err = io.WriteString(w, "<div>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_3 := `email:`
-		_, err = io.WriteString(w, var_3)
+		_, err = io.WriteString(w, `<div>email:<a href="`)
 		if err != nil {
 			return err
 		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<a")
+		var var_3 templ.SafeURL = templ.SanitizeURL(templ.URL("mailto: " + s))
+		_, err = io.WriteString(w, templ.EscapeString(string(var_3)))
 		if err != nil {
 			return err
 		}
-		// Element Attributes
-		_, err = io.WriteString(w, " href=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		var var_4 templ.SafeURL = templ.URL("mailto: " + s)
-		_, err = io.WriteString(w, templ.EscapeString(string(var_4)))
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `">`)
 		if err != nil {
 			return err
 		}
@@ -133,11 +72,7 @@ func email(s string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</a>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</div>")
+		_, err = io.WriteString(w, `</a></div>`)
 		if err != nil {
 			return err
 		}
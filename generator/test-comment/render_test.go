@@ -0,0 +1,26 @@
+package testcomment
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const expected = `<!-- HTML comments are rendered. -->` +
+	`<div>` +
+	"<!--\n\t\t\tMultiline HTML comments are rendered too, but `expressions` are not evaluated: { content }\n\t\t-->" +
+	`<p>content</p>` +
+	`</div>`
+
+func TestHTML(t *testing.T) {
+	w := new(strings.Builder)
+	err := render("content").Render(context.Background(), w)
+	if err != nil {
+		t.Errorf("failed to render: %v", err)
+	}
+	if diff := cmp.Diff(expected, w.String()); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,35 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package testcomment
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+
+func render(content string) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
+		ctx, _ = templ.RenderedScriptsFromContext(ctx)
+		var_1 := ctx
+		ctx = templ.ClearChildren(var_1)
+		_, err = io.WriteString(w, `<!-- HTML comments are rendered. --><div><!--
+			Multiline HTML comments are rendered too, but ` + "`" + `expressions` + "`" + ` are not evaluated: { content }
+		--><p>`)
+		if err != nil {
+			return err
+		}
+		// StringExpression
+		_, err = io.WriteString(w, templ.EscapeString(content))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `</p></div>`)
+		if err != nil {
+			return err
+		}
+		return err
+	})
+}
+
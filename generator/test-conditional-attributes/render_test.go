@@ -0,0 +1,69 @@
+package testconditionalattributes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHTML(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:  "active link",
+			input: render(t, NavLink("/", "Home", true)),
+			expected: `<style type="text/css">` + string(active().(templ.ComponentCSSClass).Class) + `</style>` +
+				`<script type="text/javascript">` + onSelect().Function + `</script>` +
+				`<a href="/" class="` + active().ClassName() + `" aria-current="page" onclick="` + onSelect().Call + `">Home</a>`,
+		},
+		{
+			name:     "inactive link",
+			input:    render(t, NavLink("/", "Home", false)),
+			expected: `<a href="/" class="inactive">Home</a>`,
+		},
+		{
+			name:     "disabled input",
+			input:    render(t, Input(true)),
+			expected: `<input type="text" disabled>`,
+		},
+		{
+			name:     "enabled input",
+			input:    render(t, Input(false)),
+			expected: `<input type="text">`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, tt.input); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestConditionsAreEvaluatedOnce(t *testing.T) {
+	var count int
+	isActive := func() bool {
+		count++
+		return true
+	}
+	render(t, NavLinkWithCondition("/", "Home", isActive))
+	if count != 1 {
+		t.Errorf("expected the condition to be evaluated once, got %d", count)
+	}
+}
+
+func render(t *testing.T, c templ.Component) string {
+	w := new(strings.Builder)
+	if err := c.Render(context.Background(), w); err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	return w.String()
+}
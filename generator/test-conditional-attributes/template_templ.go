@@ -0,0 +1,211 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package testconditionalattributes
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+import "strings"
+
+func active() templ.CSSClass {
+	var templCSSBuilder strings.Builder
+	templCSSBuilder.WriteString(`font-weight:bold;`)
+	templCSSID := templ.CSSID(`active`, templCSSBuilder.String())
+	return templ.ComponentCSSClass{
+		ID: templCSSID,
+		Class: templ.SafeCSS(`.` + templCSSID + `{` + templCSSBuilder.String() + `}`),
+	}
+}
+
+func onSelect() templ.ComponentScript {
+	return templ.ComponentScript{
+		Name: `__templ_onSelect_c69e`,
+		Function: `function __templ_onSelect_c69e(){alert("selected");}`,
+		Call: templ.SafeScript(`__templ_onSelect_c69e`, ),
+	}
+}
+
+func NavLink(href string, text string, isActive bool) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
+		ctx, _ = templ.RenderedScriptsFromContext(ctx)
+		var_1 := ctx
+		ctx = templ.ClearChildren(var_1)
+		var var_2 bool = isActive
+		// Element CSS (conditional)
+		var var_3 templ.CSSClasses
+		if var_2 {
+			var_3 = templ.Classes(active())
+			err = templ.RenderCSS(ctx, w, var_3)
+			if err != nil {
+				return err
+			}
+		}
+		if var_2 {
+			// Element Script
+			err = templ.RenderScripts(ctx, w, onSelect())
+			if err != nil {
+				return err
+			}
+		}
+		_, err = io.WriteString(w, `<a href="`)
+		if err != nil {
+			return err
+		}
+		var var_4 templ.SafeURL = templ.SanitizeURL(templ.URL(href))
+		_, err = io.WriteString(w, templ.EscapeString(string(var_4)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `"`)
+		if err != nil {
+			return err
+		}
+		if var_2 {
+			_, err = io.WriteString(w, ` class="`)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, templ.EscapeString(var_3.String()))
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, `" aria-current="page" onclick="`)
+			if err != nil {
+				return err
+			}
+			var var_5 templ.ComponentScript = onSelect()
+			_, err = io.WriteString(w, var_5.Call)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, `"`)
+			if err != nil {
+				return err
+			}
+		} else {
+			_, err = io.WriteString(w, ` class="inactive"`)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = io.WriteString(w, `>`)
+		if err != nil {
+			return err
+		}
+		// StringExpression
+		_, err = io.WriteString(w, templ.EscapeString(text))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `</a>`)
+		if err != nil {
+			return err
+		}
+		return err
+	})
+}
+
+func Input(disabled bool) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
+		ctx, _ = templ.RenderedScriptsFromContext(ctx)
+		var_6 := ctx
+		ctx = templ.ClearChildren(var_6)
+		var var_7 bool = disabled
+		_, err = io.WriteString(w, `<input type="text"`)
+		if err != nil {
+			return err
+		}
+		if var_7 {
+			_, err = io.WriteString(w, ` disabled`)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = io.WriteString(w, `>`)
+		if err != nil {
+			return err
+		}
+		return err
+	})
+}
+
+func NavLinkWithCondition(href string, text string, isActive func() bool) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
+		ctx, _ = templ.RenderedScriptsFromContext(ctx)
+		var_8 := ctx
+		ctx = templ.ClearChildren(var_8)
+		var var_9 bool = isActive()
+		// Element CSS (conditional)
+		var var_10 templ.CSSClasses
+		if var_9 {
+			var_10 = templ.Classes(active())
+			err = templ.RenderCSS(ctx, w, var_10)
+			if err != nil {
+				return err
+			}
+		}
+		if var_9 {
+			// Element Script
+			err = templ.RenderScripts(ctx, w, onSelect())
+			if err != nil {
+				return err
+			}
+		}
+		_, err = io.WriteString(w, `<a href="`)
+		if err != nil {
+			return err
+		}
+		var var_11 templ.SafeURL = templ.SanitizeURL(templ.URL(href))
+		_, err = io.WriteString(w, templ.EscapeString(string(var_11)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `"`)
+		if err != nil {
+			return err
+		}
+		if var_9 {
+			_, err = io.WriteString(w, ` class="`)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, templ.EscapeString(var_10.String()))
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, `" onclick="`)
+			if err != nil {
+				return err
+			}
+			var var_12 templ.ComponentScript = onSelect()
+			_, err = io.WriteString(w, var_12.Call)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, `"`)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = io.WriteString(w, `>`)
+		if err != nil {
+			return err
+		}
+		// StringExpression
+		_, err = io.WriteString(w, templ.EscapeString(text))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `</a>`)
+		if err != nil {
+			return err
+		}
+		return err
+	})
+}
+
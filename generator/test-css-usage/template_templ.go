@@ -36,23 +36,13 @@ func Button(text string) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
 		// Element CSS
 		var var_2 templ.CSSClasses = templ.Classes(className(), templ.Class("&&&unsafe"), templ.SafeClass("safe"))
 		err = templ.RenderCSS(ctx, w, var_2)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "<button")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " class=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, `<button class="`)
 		if err != nil {
 			return err
 		}
@@ -60,15 +50,7 @@ func Button(text string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " type=\"button\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `" type="button">`)
 		if err != nil {
 			return err
 		}
@@ -77,7 +59,7 @@ func Button(text string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</button>")
+		_, err = io.WriteString(w, `</button>`)
 		if err != nil {
 			return err
 		}
@@ -101,23 +83,13 @@ func ThreeButtons() templ.Component {
 		if err != nil {
 			return err
 		}
-		// Element (standard)
 		// Element CSS
 		var var_4 templ.CSSClasses = templ.Classes(green())
 		err = templ.RenderCSS(ctx, w, var_4)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "<button")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " class=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, `<button class="`)
 		if err != nil {
 			return err
 		}
@@ -125,15 +97,7 @@ func ThreeButtons() templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " type=\"button\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `" type="button">`)
 		if err != nil {
 			return err
 		}
@@ -142,7 +106,7 @@ func ThreeButtons() templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</button>")
+		_, err = io.WriteString(w, `</button>`)
 		if err != nil {
 			return err
 		}
@@ -14,82 +14,7 @@ func Layout(title, content string) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// DocType
-		_, err = io.WriteString(w, `<!doctype html>`)
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<html")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " lang=\"en\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<head>")
-		if err != nil {
-			return err
-		}
-		// Element (void)
-		_, err = io.WriteString(w, "<meta")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " charset=\"UTF-8\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (void)
-		_, err = io.WriteString(w, "<meta")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " http-equiv=\"X-UA-Compatible\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " content=\"IE=edge\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (void)
-		_, err = io.WriteString(w, "<meta")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " name=\"viewport\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " content=\"width=device-width, initial-scale=1.0\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<title>")
+		_, err = io.WriteString(w, `<!doctype html><html lang="en"><head><meta charset="UTF-8"><meta http-equiv="X-UA-Compatible" content="IE=edge"><meta name="viewport" content="width=device-width, initial-scale=1.0"><title>`)
 		if err != nil {
 			return err
 		}
@@ -98,16 +23,7 @@ func Layout(title, content string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</title>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</head>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<body>")
+		_, err = io.WriteString(w, `</title></head><body>`)
 		if err != nil {
 			return err
 		}
@@ -116,11 +32,7 @@ func Layout(title, content string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</body>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</html>")
+		_, err = io.WriteString(w, `</body></html>`)
 		if err != nil {
 			return err
 		}
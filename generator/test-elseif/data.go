@@ -0,0 +1,12 @@
+package elseif
+
+type data struct {
+}
+
+func (d data) IsTrue() bool {
+	return false
+}
+
+func (d data) IsAlsoTrue() bool {
+	return true
+}
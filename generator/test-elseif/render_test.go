@@ -0,0 +1,22 @@
+package elseif
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const expected = `Also true`
+
+func TestRender(t *testing.T) {
+	w := new(strings.Builder)
+	err := render(data{}).Render(context.Background(), w)
+	if err != nil {
+		t.Errorf("failed to render: %v", err)
+	}
+	if diff := cmp.Diff(expected, w.String()); diff != "" {
+		t.Error(diff)
+	}
+}
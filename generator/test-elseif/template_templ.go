@@ -0,0 +1,40 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package elseif
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+
+func render(d data) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
+		ctx, _ = templ.RenderedScriptsFromContext(ctx)
+		var_1 := ctx
+		ctx = templ.ClearChildren(var_1)
+		// If
+		if d.IsTrue() {
+			// StringExpression
+			_, err = io.WriteString(w, templ.EscapeString("True"))
+			if err != nil {
+				return err
+			}
+		} else if d.IsAlsoTrue() {
+			// StringExpression
+			_, err = io.WriteString(w, templ.EscapeString("Also true"))
+			if err != nil {
+				return err
+			}
+		} else {
+			// StringExpression
+			_, err = io.WriteString(w, templ.EscapeString("False"))
+			if err != nil {
+				return err
+			}
+		}
+		return err
+	})
+}
+
@@ -16,8 +16,7 @@ func render(items []string) templ.Component {
 		ctx = templ.ClearChildren(var_1)
 		// For
 		for _, item := range items {
-			// Element (standard)
-			_, err = io.WriteString(w, "<div>")
+			_, err = io.WriteString(w, `<div>`)
 			if err != nil {
 				return err
 			}
@@ -26,7 +25,7 @@ func render(items []string) templ.Component {
 			if err != nil {
 				return err
 			}
-			_, err = io.WriteString(w, "</div>")
+			_, err = io.WriteString(w, `</div>`)
 			if err != nil {
 				return err
 			}
@@ -32,3 +32,60 @@ func TestHTML(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+// recordingWriter records each call to Write.
+type recordingWriter struct {
+	writes []string
+}
+
+func (w *recordingWriter) Write(p []byte) (n int, err error) {
+	w.writes = append(w.writes, string(p))
+	return len(p), nil
+}
+
+func TestConstantsAreWrittenTogether(t *testing.T) {
+	w := new(recordingWriter)
+	err := render(person{
+		name:  "Luiz Bonfa",
+		email: "luiz@example.com",
+	}).Render(context.Background(), w)
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	// Adjacent constants, such as closing and opening tags, are written in a single call to Write.
+	expected := []string{
+		`<div><h1>`,
+		`Luiz Bonfa`,
+		`</h1><div style="font-family: &#39;sans-serif&#39;" id="test" data-contents="`,
+		`something with &#34;quotes&#34; and a &lt;tag&gt;`,
+		`"><div>email:<a href="`,
+		`mailto: luiz@example.com`,
+		`">`,
+		`luiz@example.com`,
+		`</a></div></div></div><hr`,
+		` noshade`,
+		`><hr optionA`,
+		` optionB`,
+		` optionC="other"`,
+		`><hr noshade>`,
+	}
+	if diff := cmp.Diff(expected, w.writes); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func BenchmarkHTML(b *testing.B) {
+	b.ReportAllocs()
+	p := person{
+		name:  "Luiz Bonfa",
+		email: "luiz@example.com",
+	}
+	w := new(strings.Builder)
+	for i := 0; i < b.N; i++ {
+		err := render(p).Render(context.Background(), w)
+		if err != nil {
+			b.Errorf("failed to render: %v", err)
+		}
+		w.Reset()
+	}
+}
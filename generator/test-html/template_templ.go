@@ -14,13 +14,7 @@ func render(p person) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
-		_, err = io.WriteString(w, "<div>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<h1>")
+		_, err = io.WriteString(w, `<div><h1>`)
 		if err != nil {
 			return err
 		}
@@ -29,29 +23,7 @@ func render(p person) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</h1>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<div")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " style=\"font-family: &#39;sans-serif&#39;\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " id=\"test\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " data-contents=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, `</h1><div style="font-family: &#39;sans-serif&#39;" id="test" data-contents="`)
 		if err != nil {
 			return err
 		}
@@ -59,49 +31,16 @@ func render(p person) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<div>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_2 := `email:`
-		_, err = io.WriteString(w, var_2)
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<a")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " href=")
+		_, err = io.WriteString(w, `"><div>email:<a href="`)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
+		var var_2 templ.SafeURL = templ.SanitizeURL(templ.URL("mailto: " + p.email))
+		_, err = io.WriteString(w, templ.EscapeString(string(var_2)))
 		if err != nil {
 			return err
 		}
-		var var_3 templ.SafeURL = templ.URL("mailto: " + p.email)
-		_, err = io.WriteString(w, templ.EscapeString(string(var_3)))
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `">`)
 		if err != nil {
 			return err
 		}
@@ -110,79 +49,37 @@ func render(p person) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</a>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</div>")
+		_, err = io.WriteString(w, `</a></div></div></div><hr`)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</div>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</div>")
-		if err != nil {
-			return err
-		}
-		// Element (void)
-		_, err = io.WriteString(w, "<hr")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
 		if true {
-			_, err = io.WriteString(w, " noshade")
+			_, err = io.WriteString(w, ` noshade`)
 			if err != nil {
 				return err
 			}
 		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (void)
-		_, err = io.WriteString(w, "<hr")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " optionA")
+		_, err = io.WriteString(w, `><hr optionA`)
 		if err != nil {
 			return err
 		}
 		if true {
-			_, err = io.WriteString(w, " optionB")
+			_, err = io.WriteString(w, ` optionB`)
 			if err != nil {
 				return err
 			}
 		}
-		_, err = io.WriteString(w, " optionC=\"other\"")
+		_, err = io.WriteString(w, ` optionC="other"`)
 		if err != nil {
 			return err
 		}
 		if false {
-			_, err = io.WriteString(w, " optionD")
+			_, err = io.WriteString(w, ` optionD`)
 			if err != nil {
 				return err
 			}
 		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (void)
-		_, err = io.WriteString(w, "<hr")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " noshade")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `><hr noshade>`)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,50 @@
+package testpanic
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+)
+
+func TestPanic(t *testing.T) {
+	var handled error
+	h := templ.Handler(render(nil), templ.WithPanicRecovery(), templ.WithErrorHandler(func(r *http.Request, err error) http.Handler {
+		handled = err
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	var pe templ.PanicError
+	if !errors.As(handled, &pe) {
+		t.Fatalf("expected a PanicError, got %v", handled)
+	}
+	if filepath.Base(pe.File) != "template_templ.go" {
+		t.Errorf("expected the panic to be located in template_templ.go, got %q", pe.File)
+	}
+	expectedLine := lineContaining(t, "template_templ.go", "p.Name")
+	if pe.Line != expectedLine {
+		t.Errorf("expected the panic to be located on line %d, got %d", expectedLine, pe.Line)
+	}
+}
+
+func lineContaining(t *testing.T, fileName, s string) int {
+	contents, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	for i, line := range strings.Split(string(contents), "\n") {
+		if strings.Contains(line, s) {
+			return i + 1
+		}
+	}
+	t.Fatalf("%q not found in %s", s, fileName)
+	return 0
+}
@@ -0,0 +1,38 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package testpanic
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+
+// GoExpression
+type person struct {
+	Name string
+}
+
+func render(p *person) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
+		ctx, _ = templ.RenderedScriptsFromContext(ctx)
+		var_1 := ctx
+		ctx = templ.ClearChildren(var_1)
+		_, err = io.WriteString(w, `<div>`)
+		if err != nil {
+			return err
+		}
+		// StringExpression
+		_, err = io.WriteString(w, templ.EscapeString(p.Name))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `</div>`)
+		if err != nil {
+			return err
+		}
+		return err
+	})
+}
+
@@ -14,18 +14,7 @@ func StyleElement() templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-// RawElement
-		_, err = io.WriteString(w, "<style>")
-		if err != nil {
-			return err
-		}
-// Text
-var_2 := `<!-- Some stuff -->`
-_, err = io.WriteString(w, var_2)
-if err != nil {
-	return err
-}
-		_, err = io.WriteString(w, "</style>")
+		_, err = io.WriteString(w, `<style><!-- Some stuff --></style>`)
 		if err != nil {
 			return err
 		}
@@ -40,34 +29,14 @@ func ScriptElement() templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
-		var_3 := ctx
-		ctx = templ.ClearChildren(var_3)
-// RawElement
-		_, err = io.WriteString(w, "<script")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " type=\"text/javascript\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-// Text
-var_4 := `
+		var_2 := ctx
+		ctx = templ.ClearChildren(var_2)
+		_, err = io.WriteString(w, `<script type="text/javascript">
     $("div").marquee();
     function test() {
           window.open("https://example.com")
     }
-  `
-_, err = io.WriteString(w, var_4)
-if err != nil {
-	return err
-}
-		_, err = io.WriteString(w, "</script>")
+  </script>`)
 		if err != nil {
 			return err
 		}
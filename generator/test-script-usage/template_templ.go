@@ -30,22 +30,12 @@ func Button(text string) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
 		// Element Script
 		err = templ.RenderScripts(ctx, w, withParameters("test", text, 123), withoutParameters())
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "<button")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " onClick=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, `<button onClick="`)
 		if err != nil {
 			return err
 		}
@@ -54,15 +44,7 @@ func Button(text string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " onMouseover=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, `" onMouseover="`)
 		if err != nil {
 			return err
 		}
@@ -71,15 +53,7 @@ func Button(text string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " type=\"button\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `" type="button">`)
 		if err != nil {
 			return err
 		}
@@ -88,7 +62,7 @@ func Button(text string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</button>")
+		_, err = io.WriteString(w, `</button>`)
 		if err != nil {
 			return err
 		}
@@ -112,31 +86,7 @@ func ThreeButtons() templ.Component {
 		if err != nil {
 			return err
 		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<button")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " onMouseover=\"console.log(&#39;mouseover&#39;)\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " type=\"button\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_5 := `Button C`
-		_, err = io.WriteString(w, var_5)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</button>")
+		_, err = io.WriteString(w, `<button onMouseover="console.log(&#39;mouseover&#39;)" type="button">Button C</button>`)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,92 @@
+package testspreadattributes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+	"github.com/google/go-cmp/cmp"
+)
+
+const expected = `<div>` +
+	`<a checked data-id="123" href="about:invalid#TemplFailedSanitizationURL" title="&lt;b&gt;">text</a>` +
+	`<input type="text" checked data-id="123" href="about:invalid#TemplFailedSanitizationURL" title="&lt;b&gt;">` +
+	`</div>`
+
+func TestHTML(t *testing.T) {
+	component := BasicTemplate(templ.Attributes{
+		// Bool attributes are rendered if true.
+		"checked":  true,
+		"disabled": false,
+		// Values are escaped.
+		"title":   "<b>",
+		"data-id": 123,
+		// URLs are sanitized.
+		"href": "javascript:alert('xss')",
+		// Event handlers must be scripts.
+		"onmouseover": "alert('xss')",
+		// Invalid names are skipped.
+		`" onerror="alert('xss')`: "",
+	})
+
+	w := new(strings.Builder)
+	err := component.Render(context.Background(), w)
+	if err != nil {
+		t.Errorf("failed to render: %v", err)
+	}
+	if diff := cmp.Diff(expected, w.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestScripts(t *testing.T) {
+	component := BasicTemplate(templ.Attributes{
+		"onclick": onClick(),
+	})
+
+	w := new(strings.Builder)
+	err := component.Render(context.Background(), w)
+	if err != nil {
+		t.Errorf("failed to render: %v", err)
+	}
+	script := `<script type="text/javascript">` + onClick().Function + `</script>`
+	if count := strings.Count(w.String(), script); count != 1 {
+		t.Errorf("expected the script to be rendered once, but it was rendered %d times in %q", count, w.String())
+	}
+	handler := `onclick="` + onClick().Call + `"`
+	if count := strings.Count(w.String(), handler); count != 2 {
+		t.Errorf("expected the handler to be rendered on both elements, but it was rendered %d times in %q", count, w.String())
+	}
+}
+
+func TestMultiLineAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		active   bool
+		expected string
+	}{
+		{
+			name:     "active",
+			active:   true,
+			expected: `<a href="/" class="active" aria-current="page" data-id="123">text</a>`,
+		},
+		{
+			name:     "inactive",
+			active:   false,
+			expected: `<a href="/" class="inactive" data-id="123">text</a>`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			w := new(strings.Builder)
+			if err := MultiLineTemplate(templ.Attributes{"data-id": 123}, tt.active).Render(context.Background(), w); err != nil {
+				t.Fatalf("failed to render: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, w.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
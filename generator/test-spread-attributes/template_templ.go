@@ -0,0 +1,108 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package testspreadattributes
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+
+func onClick() templ.ComponentScript {
+	return templ.ComponentScript{
+		Name: `__templ_onClick_657d`,
+		Function: `function __templ_onClick_657d(){alert("clicked");}`,
+		Call: templ.SafeScript(`__templ_onClick_657d`, ),
+	}
+}
+
+func BasicTemplate(spread templ.Attributes) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
+		ctx, _ = templ.RenderedScriptsFromContext(ctx)
+		var_1 := ctx
+		ctx = templ.ClearChildren(var_1)
+		_, err = io.WriteString(w, `<div>`)
+		if err != nil {
+			return err
+		}
+		// Element Script (spread attributes)
+		var var_2 templ.Attributes = spread
+		err = templ.RenderScripts(ctx, w, var_2.Scripts()...)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `<a`)
+		if err != nil {
+			return err
+		}
+		err = templ.RenderAttributes(ctx, w, var_2)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `>text</a>`)
+		if err != nil {
+			return err
+		}
+		// Element Script (spread attributes)
+		var var_3 templ.Attributes = spread
+		err = templ.RenderScripts(ctx, w, var_3.Scripts()...)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `<input type="text"`)
+		if err != nil {
+			return err
+		}
+		err = templ.RenderAttributes(ctx, w, var_3)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `></div>`)
+		if err != nil {
+			return err
+		}
+		return err
+	})
+}
+
+func MultiLineTemplate(spread templ.Attributes, active bool) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
+		ctx, _ = templ.RenderedScriptsFromContext(ctx)
+		var_4 := ctx
+		ctx = templ.ClearChildren(var_4)
+		var var_5 bool = active
+		// Element Script (spread attributes)
+		var var_6 templ.Attributes = spread
+		err = templ.RenderScripts(ctx, w, var_6.Scripts()...)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `<a href="/"`)
+		if err != nil {
+			return err
+		}
+		if var_5 {
+			_, err = io.WriteString(w, ` class="active" aria-current="page"`)
+			if err != nil {
+				return err
+			}
+		} else {
+			_, err = io.WriteString(w, ` class="inactive"`)
+			if err != nil {
+				return err
+			}
+		}
+		err = templ.RenderAttributes(ctx, w, var_6)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `>text</a>`)
+		if err != nil {
+			return err
+		}
+		return err
+	})
+}
+
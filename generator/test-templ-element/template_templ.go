@@ -17,17 +17,7 @@ func wrapper(index int) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
-		_, err = io.WriteString(w, "<div")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " id=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, `<div id="`)
 		if err != nil {
 			return err
 		}
@@ -35,11 +25,7 @@ func wrapper(index int) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
+		_, err = io.WriteString(w, `">`)
 		if err != nil {
 			return err
 		}
@@ -48,7 +34,7 @@ func wrapper(index int) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</div>")
+		_, err = io.WriteString(w, `</div>`)
 		if err != nil {
 			return err
 		}
@@ -64,40 +50,19 @@ func template() templ.Component {
 		ctx = templ.ClearChildren(var_2)
 		// TemplElement
 		var_3 := templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
-			// Text
-			var_4 := `child1`
-			_, err = io.WriteString(w, var_4)
-			if err != nil {
-				return err
-			}
-			// Whitespace (normalised)
-			_, err = io.WriteString(w, ` `)
+			_, err = io.WriteString(w, `child1 `)
 			if err != nil {
 				return err
 			}
 			// TemplElement
-			var_5 := templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
-				// Text
-				var_6 := `child2`
-				_, err = io.WriteString(w, var_6)
-				if err != nil {
-					return err
-				}
-				// Whitespace (normalised)
-				_, err = io.WriteString(w, ` `)
+			var_4 := templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+				_, err = io.WriteString(w, `child2 `)
 				if err != nil {
 					return err
 				}
 				// TemplElement
-				var_7 := templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
-					// Text
-					var_8 := `child3`
-					_, err = io.WriteString(w, var_8)
-					if err != nil {
-						return err
-					}
-					// Whitespace (normalised)
-					_, err = io.WriteString(w, ` `)
+				var_5 := templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+					_, err = io.WriteString(w, `child3 `)
 					if err != nil {
 						return err
 					}
@@ -108,13 +73,13 @@ func template() templ.Component {
 					}
 					return err
 				})
-				err = wrapper(3).Render(templ.WithChildren(ctx, var_7), w)
+				err = wrapper(3).Render(templ.WithChildren(ctx, var_5), w)
 				if err != nil {
 					return err
 				}
 				return err
 			})
-			err = wrapper(2).Render(templ.WithChildren(ctx, var_5), w)
+			err = wrapper(2).Render(templ.WithChildren(ctx, var_4), w)
 			if err != nil {
 				return err
 			}
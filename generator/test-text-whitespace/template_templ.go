@@ -14,32 +14,18 @@ func WhitespaceIsAddedWithinTemplStatements() templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
-		_, err = io.WriteString(w, "<p>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_2 := `This is some text.`
-		_, err = io.WriteString(w, var_2)
-		if err != nil {
-			return err
-		}
-		// Whitespace (normalised)
-		_, err = io.WriteString(w, ` `)
+		_, err = io.WriteString(w, `<p>This is some text. `)
 		if err != nil {
 			return err
 		}
 		// If
 		if true {
-			// Text
-			var_3 := `So is this.`
-			_, err = io.WriteString(w, var_3)
+			_, err = io.WriteString(w, `So is this.`)
 			if err != nil {
 				return err
 			}
 		}
-		_, err = io.WriteString(w, "</p>")
+		_, err = io.WriteString(w, `</p>`)
 		if err != nil {
 			return err
 		}
@@ -54,46 +40,9 @@ func InlineElementsAreNotPadded() templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
-		var_4 := ctx
-		ctx = templ.ClearChildren(var_4)
-		// Element (standard)
-		_, err = io.WriteString(w, "<p>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_5 := `Inline text `
-		_, err = io.WriteString(w, var_5)
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<b>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_6 := `is spaced properly`
-		_, err = io.WriteString(w, var_6)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</b>")
-		if err != nil {
-			return err
-		}
-		// Whitespace (normalised)
-		_, err = io.WriteString(w, ` `)
-		if err != nil {
-			return err
-		}
-		// Text
-		var_7 := `without adding extra spaces.`
-		_, err = io.WriteString(w, var_7)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</p>")
+		var_2 := ctx
+		ctx = templ.ClearChildren(var_2)
+		_, err = io.WriteString(w, `<p>Inline text <b>is spaced properly</b> without adding extra spaces.</p>`)
 		if err != nil {
 			return err
 		}
@@ -108,42 +57,9 @@ func WhiteSpaceInHTMLIsNormalised() templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
-		var_8 := ctx
-		ctx = templ.ClearChildren(var_8)
-		// Element (standard)
-		_, err = io.WriteString(w, "<p>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_9 := `newlines and other whitespace are stripped`
-		_, err = io.WriteString(w, var_9)
-		if err != nil {
-			return err
-		}
-		// Whitespace (normalised)
-		_, err = io.WriteString(w, ` `)
-		if err != nil {
-			return err
-		}
-		// Text
-		var_10 := `but it is normalised`
-		_, err = io.WriteString(w, var_10)
-		if err != nil {
-			return err
-		}
-		// Whitespace (normalised)
-		_, err = io.WriteString(w, ` `)
-		if err != nil {
-			return err
-		}
-		// Text
-		var_11 := `like HTML.`
-		_, err = io.WriteString(w, var_11)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</p>")
+		var_3 := ctx
+		ctx = templ.ClearChildren(var_3)
+		_, err = io.WriteString(w, `<p>newlines and other whitespace are stripped but it is normalised like HTML.</p>`)
 		if err != nil {
 			return err
 		}
@@ -158,16 +74,9 @@ func WhiteSpaceAroundValues() templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
-		var_12 := ctx
-		ctx = templ.ClearChildren(var_12)
-		// Element (standard)
-		_, err = io.WriteString(w, "<p>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_13 := `templ allows `
-		_, err = io.WriteString(w, var_13)
+		var_4 := ctx
+		ctx = templ.ClearChildren(var_4)
+		_, err = io.WriteString(w, `<p>templ allows `)
 		if err != nil {
 			return err
 		}
@@ -176,18 +85,7 @@ func WhiteSpaceAroundValues() templ.Component {
 		if err != nil {
 			return err
 		}
-		// Whitespace (normalised)
-		_, err = io.WriteString(w, ` `)
-		if err != nil {
-			return err
-		}
-		// Text
-		var_14 := `to be included in sentences.`
-		_, err = io.WriteString(w, var_14)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</p>")
+		_, err = io.WriteString(w, ` to be included in sentences.</p>`)
 		if err != nil {
 			return err
 		}
@@ -14,14 +14,7 @@ func BasicTemplate(name string) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
-		_, err = io.WriteString(w, "<div>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_2 := `Name: `
-		_, err = io.WriteString(w, var_2)
+		_, err = io.WriteString(w, `<div>Name: `)
 		if err != nil {
 			return err
 		}
@@ -30,48 +23,7 @@ func BasicTemplate(name string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</div>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<div>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_3 := `Text ` + "`" + `with backticks` + "`" + ``
-		_, err = io.WriteString(w, var_3)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</div>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<div>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_4 := `Text ` + "`" + `with backtick`
-		_, err = io.WriteString(w, var_4)
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</div>")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<div>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_5 := `Text ` + "`" + `with backtick alongside variable: `
-		_, err = io.WriteString(w, var_5)
+		_, err = io.WriteString(w, `</div><div>Text ` + "`" + `with backticks` + "`" + `</div><div>Text ` + "`" + `with backtick</div><div>Text ` + "`" + `with backtick alongside variable: `)
 		if err != nil {
 			return err
 		}
@@ -80,7 +32,7 @@ func BasicTemplate(name string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</div>")
+		_, err = io.WriteString(w, `</div>`)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,65 @@
+package testurlattributes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const failed = "about:invalid#TemplFailedSanitizationURL"
+
+func TestHTML(t *testing.T) {
+	var tests = []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{
+			name: "unsafe URLs are sanitized",
+			url:  `javascript:alert("xss")`,
+			expected: `<a href="` + failed + `">Link</a>` +
+				`<img src="` + failed + `">` +
+				`<img srcset="` + failed + ` 1x, /image-2x.png 2x">` +
+				`<form action="` + failed + `">` +
+				`<button formaction="` + failed + `">Submit</button>` +
+				`</form>` +
+				`<iframe src="` + failed + `"></iframe>` +
+				`<object data="` + failed + `"></object>` +
+				`<video poster="` + failed + `"></video>` +
+				`<div data-url="` + failed + `"></div>` +
+				`<svg><use xlink:href="` + failed + `"></use></svg>` +
+				`<a href="javascript:alert(&#34;xss&#34;)">Trusted</a>`,
+		},
+		{
+			name: "safe URLs are escaped",
+			url:  `/search?q=a&b="c"`,
+			expected: `<a href="/search?q=a&amp;b=&#34;c&#34;">Link</a>` +
+				`<img src="/search?q=a&amp;b=&#34;c&#34;">` +
+				`<img srcset="/search?q=a&amp;b=&#34;c&#34; 1x, /image-2x.png 2x">` +
+				`<form action="/search?q=a&amp;b=&#34;c&#34;">` +
+				`<button formaction="/search?q=a&amp;b=&#34;c&#34;">Submit</button>` +
+				`</form>` +
+				`<iframe src="/search?q=a&amp;b=&#34;c&#34;"></iframe>` +
+				`<object data="/search?q=a&amp;b=&#34;c&#34;"></object>` +
+				`<video poster="/search?q=a&amp;b=&#34;c&#34;"></video>` +
+				`<div data-url="/search?q=a&amp;b=&#34;c&#34;"></div>` +
+				`<svg><use xlink:href="/search?q=a&amp;b=&#34;c&#34;"></use></svg>` +
+				`<a href="/search?q=a&amp;b=&#34;c&#34;">Trusted</a>`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			w := new(strings.Builder)
+			err := render(tt.url).Render(context.Background(), w)
+			if err != nil {
+				t.Errorf("failed to render: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, w.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Code generated by templ@(devel) DO NOT EDIT.
+
+package testurlattributes
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import "context"
+import "io"
+
+func render(url string) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
+		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
+		ctx, _ = templ.RenderedScriptsFromContext(ctx)
+		var_1 := ctx
+		ctx = templ.ClearChildren(var_1)
+		_, err = io.WriteString(w, `<a href="`)
+		if err != nil {
+			return err
+		}
+		var var_2 templ.SafeURL = templ.SanitizeURL(url)
+		_, err = io.WriteString(w, templ.EscapeString(string(var_2)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `">Link</a><img src="`)
+		if err != nil {
+			return err
+		}
+		var var_3 templ.SafeURL = templ.SanitizeURL(url)
+		_, err = io.WriteString(w, templ.EscapeString(string(var_3)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `"><img srcset="`)
+		if err != nil {
+			return err
+		}
+		var var_4 templ.SafeURL = templ.SanitizeSrcset(url + " 1x, /image-2x.png 2x")
+		_, err = io.WriteString(w, templ.EscapeString(string(var_4)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `"><form action="`)
+		if err != nil {
+			return err
+		}
+		var var_5 templ.SafeURL = templ.SanitizeURL(url)
+		_, err = io.WriteString(w, templ.EscapeString(string(var_5)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `"><button formaction="`)
+		if err != nil {
+			return err
+		}
+		var var_6 templ.SafeURL = templ.SanitizeURL(url)
+		_, err = io.WriteString(w, templ.EscapeString(string(var_6)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `">Submit</button></form><iframe src="`)
+		if err != nil {
+			return err
+		}
+		var var_7 templ.SafeURL = templ.SanitizeURL(url)
+		_, err = io.WriteString(w, templ.EscapeString(string(var_7)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `"></iframe><object data="`)
+		if err != nil {
+			return err
+		}
+		var var_8 templ.SafeURL = templ.SanitizeURL(url)
+		_, err = io.WriteString(w, templ.EscapeString(string(var_8)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `"></object><video poster="`)
+		if err != nil {
+			return err
+		}
+		var var_9 templ.SafeURL = templ.SanitizeURL(url)
+		_, err = io.WriteString(w, templ.EscapeString(string(var_9)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `"></video><div data-url="`)
+		if err != nil {
+			return err
+		}
+		var var_10 templ.SafeURL = templ.SanitizeURL(url)
+		_, err = io.WriteString(w, templ.EscapeString(string(var_10)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `"></div><svg><use xlink:href="`)
+		if err != nil {
+			return err
+		}
+		var var_11 templ.SafeURL = templ.SanitizeURL(url)
+		_, err = io.WriteString(w, templ.EscapeString(string(var_11)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `"></use></svg><a href="`)
+		if err != nil {
+			return err
+		}
+		var var_12 templ.SafeURL = templ.SanitizeURL(templ.SafeURL(url))
+		_, err = io.WriteString(w, templ.EscapeString(string(var_12)))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, `">Trusted</a>`)
+		if err != nil {
+			return err
+		}
+		return err
+	})
+}
+
@@ -14,32 +14,7 @@ func render() templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (void)
-		_, err = io.WriteString(w, "<br>")
-		if err != nil {
-			return err
-		}
-		// Element (void)
-		_, err = io.WriteString(w, "<img")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " src=\"https://example.com/image.png\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (void)
-		_, err = io.WriteString(w, "<br>")
-		if err != nil {
-			return err
-		}
-		// Element (void)
-		_, err = io.WriteString(w, "<br>")
+		_, err = io.WriteString(w, `<br><img src="https://example.com/image.png"><br><br>`)
 		if err != nil {
 			return err
 		}
@@ -3,6 +3,7 @@ module github.com/a-h/templ
 go 1.16
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/PuerkitoBio/goquery v1.8.0
 	github.com/a-h/lexical v0.0.53
 	github.com/a-h/pathvars v0.0.0-20200320143331-78b263b728e2
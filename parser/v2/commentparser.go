@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"io"
+
+	"github.com/a-h/lexical/parse"
+)
+
+func newCommentParser() commentParser {
+	return commentParser{}
+}
+
+type commentParser struct {
+}
+
+// <!--
+var htmlCommentStartParser = parse.String("<!--")
+
+// -->
+var htmlCommentEndParser = parse.String("-->")
+
+// {/* or { /*
+var templCommentStartParser = parse.Or(parse.String("{/*"), parse.String("{ /*"))
+
+// */} or */ }
+var templCommentEndParser = parse.Or(parse.String("*/}"), parse.String("*/ }"))
+
+func (p commentParser) Parse(pi parse.Input) parse.Result {
+	if pr := htmlCommentStartParser(pi); pr.Success {
+		return p.parseContents(pi, CommentTypeHTML, htmlCommentEndParser, "unclosed HTML comment (expected '-->')")
+	}
+	if pr := templCommentStartParser(pi); pr.Success {
+		return p.parseContents(pi, CommentTypeTempl, templCommentEndParser, "unclosed templ comment (expected '*/}')")
+	}
+	return parse.Failure("commentParser", nil)
+}
+
+func (p commentParser) parseContents(pi parse.Input, t CommentType, end parse.Function, msg string) parse.Result {
+	// Once a comment has started, take everything until the end.
+	from := NewPositionFromInput(pi)
+	pr := parse.StringUntil(end)(pi)
+	if pr.Error != nil && pr.Error != io.EOF {
+		return pr
+	}
+	if !pr.Success {
+		return parse.Failure("commentParser", newParseError(msg, from, NewPositionFromInput(pi)))
+	}
+	r := Comment{
+		Contents: pr.Item.(string),
+		Type:     t,
+	}
+
+	// Clear the end of the comment.
+	if pr = end(pi); !pr.Success {
+		return parse.Failure("commentParser", newParseError(msg, from, NewPositionFromInput(pi)))
+	}
+
+	return parse.Success("commentParser", r, nil)
+}
@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/a-h/lexical/input"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCommentParser(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		expected Comment
+	}{
+		{
+			name:  "comment: HTML",
+			input: `<!-- single line comment -->`,
+			expected: Comment{
+				Contents: " single line comment ",
+				Type:     CommentTypeHTML,
+			},
+		},
+		{
+			name: "comment: HTML, multiline",
+			input: `<!--
+	multiline
+	comment
+-->`,
+			expected: Comment{
+				Contents: "\n\tmultiline\n\tcomment\n",
+				Type:     CommentTypeHTML,
+			},
+		},
+		{
+			name:  "comment: HTML, containing tags",
+			input: `<!-- <div>{ "disabled" }</div> -->`,
+			expected: Comment{
+				Contents: ` <div>{ "disabled" }</div> `,
+				Type:     CommentTypeHTML,
+			},
+		},
+		{
+			name:  "comment: templ",
+			input: `{/* templ comment */}`,
+			expected: Comment{
+				Contents: " templ comment ",
+				Type:     CommentTypeTempl,
+			},
+		},
+		{
+			name:  "comment: templ, with padding",
+			input: `{ /* templ comment */ }`,
+			expected: Comment{
+				Contents: " templ comment ",
+				Type:     CommentTypeTempl,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			input := input.NewFromString(tt.input)
+			result := newCommentParser().Parse(input)
+			if result.Error != nil {
+				t.Fatalf("parser error: %v", result.Error)
+			}
+			if !result.Success {
+				t.Fatalf("failed to parse at %d", input.Index())
+			}
+			if diff := cmp.Diff(tt.expected, result.Item); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestCommentParserErrors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		expected error
+	}{
+		{
+			name:  "comment: unclosed HTML comment",
+			input: `<!-- comment`,
+			expected: newParseError("unclosed HTML comment (expected '-->')",
+				Position{
+					Index: 4,
+					Line:  0,
+					Col:   4,
+				},
+				Position{
+					Index: 13,
+					Line:  0,
+					Col:   12,
+				}),
+		},
+		{
+			name:  "comment: unclosed templ comment",
+			input: `{/* comment`,
+			expected: newParseError("unclosed templ comment (expected '*/}')",
+				Position{
+					Index: 3,
+					Line:  0,
+					Col:   3,
+				},
+				Position{
+					Index: 12,
+					Line:  0,
+					Col:   11,
+				}),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			input := input.NewFromString(tt.input)
+			result := newCommentParser().Parse(input)
+			if diff := cmp.Diff(tt.expected, result.Error); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
@@ -13,8 +13,9 @@ import (
 
 // Element open tag.
 type elementOpenTag struct {
-	Name       string
-	Attributes []Attribute
+	Name        string
+	Attributes  []Attribute
+	IndentAttrs bool
 }
 
 func newElementOpenTagParser() elementOpenTagParser {
@@ -25,9 +26,11 @@ type elementOpenTagParser struct {
 }
 
 func asElementOpenTag(parts []interface{}) (result interface{}, ok bool) {
+	attrs := parts[2].(elementAttributes)
 	return elementOpenTag{
-		Name:       parts[1].(string),
-		Attributes: parts[2].([]Attribute),
+		Name:        parts[1].(string),
+		Attributes:  attrs.Attributes,
+		IndentAttrs: attrs.IndentAttrs,
 	}, true
 }
 
@@ -35,7 +38,7 @@ func (p elementOpenTagParser) Parse(pi parse.Input) parse.Result {
 	return parse.All(asElementOpenTag,
 		parse.Rune('<'),
 		elementNameParser,
-		newAttributesParser().Parse,
+		elementAttributesParser,
 		parse.Optional(parse.WithStringConcatCombiner, whitespaceParser),
 		parse.Rune('>'),
 	)(pi)
@@ -59,8 +62,10 @@ var elementCloseTagParser = parse.All(asElementCloseTag,
 )
 
 // Attribute name.
-var attributeNameFirst = "abcdefghijklmnopqrstuvwxyz"
-var attributeNameSubsequent = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-"
+// In addition to standard HTML attribute names, names used by Alpine.js, htmx, Vue and
+// hyperscript are supported, e.g. @click, :class, x-on:keyup.enter, hx-on::after-request, xml:lang and _.
+var attributeNameFirst = "abcdefghijklmnopqrstuvwxyz@:_"
+var attributeNameSubsequent = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-:._@"
 var attributeNameParser = parse.Then(parse.WithStringConcatCombiner,
 	parse.RuneIn(attributeNameFirst),
 	parse.Many(parse.WithStringConcatCombiner, 0, 128, parse.RuneIn(attributeNameSubsequent)),
@@ -128,8 +133,8 @@ func (p boolConstantAttributeParser) Parse(pi parse.Input) parse.Result {
 		rewind(pi, start)
 		return parse.Failure("boolConstantAttributeParser", nil)
 	}
-	if !(next == ' ' || next == '\n' || next == '/') {
-		return parse.Failure("boolConstantAttributeParser", fmt.Errorf("boolConstantAttributeParser: expected attribute name to end with space, newline or '/>', but got %q", string(next)))
+	if !(next == ' ' || next == '\t' || next == '\r' || next == '\n' || next == '/' || next == '>') {
+		return parse.Failure("boolConstantAttributeParser", fmt.Errorf("boolConstantAttributeParser: expected attribute name to end with space, newline, '/>' or '>', but got %q", string(next)))
 	}
 
 	return parse.Success("boolConstantAttributeParser", r, nil)
@@ -236,6 +241,151 @@ func (p expressionAttributeParser) Parse(pi parse.Input) parse.Result {
 	return parse.Success("expressionAttributeParser", r, nil)
 }
 
+// ConditionalAttribute.
+func newConditionalAttributeParser() conditionalAttributeParser {
+	return conditionalAttributeParser{}
+}
+
+type conditionalAttributeParser struct {
+}
+
+// Conditional attribute blocks can contain any attribute except spread attributes and other conditional attributes.
+var conditionalAttributeContentsParser = parse.Many(attributesParser{}.asAttributeArray, 0, 255, parse.Any(
+	newBoolConstantAttributeParser().Parse,
+	newConstantAttributeParser().Parse,
+	newBoolExpressionAttributeParser().Parse,
+	newExpressionAttributeParser().Parse,
+))
+
+var conditionalAttributeElseParser = parse.All(parse.WithStringConcatCombiner,
+	optionalWhitespaceAsString,
+	parse.String("else"),
+	optionalWhitespaceAsString,
+	openBrace,
+)
+
+func (p conditionalAttributeParser) Parse(pi parse.Input) parse.Result {
+	var r ConditionalAttribute
+
+	start := pi.Index()
+	pr := whitespaceParser(pi)
+	if !pr.Success {
+		return pr
+	}
+
+	if pr = parse.String("if ")(pi); !pr.Success {
+		rewind(pi, start)
+		return pr
+	}
+
+	// Once we've seen an "if ", read until the opening brace.
+	from := NewPositionFromInput(pi)
+	pr = parse.StringUntil(openBraceWithOptionalPadding)(pi)
+	if pr.Error != nil && pr.Error != io.EOF {
+		return pr
+	}
+	if !pr.Success {
+		return parse.Failure("conditionalAttributeParser", newParseError("if: unterminated (missing '{')", from, NewPositionFromInput(pi)))
+	}
+	r.Expression = NewExpression(pr.Item.(string), from, NewPositionFromInput(pi))
+
+	// Eat " {".
+	if pr = expressionEnd(pi); !pr.Success {
+		return parse.Failure("conditionalAttributeParser", newParseError("if: unterminated (missing '{')", from, NewPositionFromInput(pi)))
+	}
+
+	// Once we've seen "if x {", the rest must be present, or it's an error.
+	// Read the 'Then' attributes.
+	if r.Then, pr = p.parseAttributes(pi); !pr.Success {
+		return pr
+	}
+
+	// Read the optional 'Else' attributes.
+	if pr = conditionalAttributeElseParser(pi); pr.Success {
+		if r.Else, pr = p.parseAttributes(pi); !pr.Success {
+			return pr
+		}
+	}
+
+	return parse.Success("conditionalAttributeParser", r, nil)
+}
+
+func (p conditionalAttributeParser) parseAttributes(pi parse.Input) (attrs []Attribute, pr parse.Result) {
+	from := NewPositionFromInput(pi)
+	pr = conditionalAttributeContentsParser(pi)
+	if !pr.Success {
+		pr = parse.Failure("conditionalAttributeParser", newParseError("if: invalid attributes", from, NewPositionFromInput(pi)))
+		return
+	}
+	attrs = pr.Item.([]Attribute)
+
+	// Eat the closing brace.
+	optionalWhitespaceParser(pi)
+	if pr = closeBrace(pi); !pr.Success {
+		pr = parse.Failure("conditionalAttributeParser", newParseError("if: missing end (expected '}')", from, NewPositionFromInput(pi)))
+	}
+	return
+}
+
+// SpreadAttributes.
+func newSpreadAttributesParser() spreadAttributesParser {
+	return spreadAttributesParser{}
+}
+
+type spreadAttributesParser struct {
+}
+
+func (p spreadAttributesParser) Parse(pi parse.Input) parse.Result {
+	var r SpreadAttributes
+
+	start := pi.Index()
+	pr := whitespaceParser(pi)
+	if !pr.Success {
+		rewind(pi, start)
+		return pr
+	}
+
+	if pr = parse.Or(parse.String("{ "), parse.String("{"))(pi); !pr.Success {
+		rewind(pi, start)
+		return pr
+	}
+
+	// Once we've seen a brace, it must be spread attributes, since other attributes don't start with a
+	// brace. Read the Go expression up to the brace that closes it, which must end with "...".
+	from := NewPositionFromInput(pi)
+	if pr = exp.Parse(pi); !pr.Success {
+		rewind(pi, from.Index)
+		return parse.Failure("spreadAttributesParser", newParseError("spread attributes: unterminated (missing closing '}')", from, from))
+	}
+	end := NewPositionFromInput(pi)
+	expression := strings.TrimRight(pr.Item.(string), " \t\r\n")
+	if !strings.HasSuffix(expression, "...") {
+		return parse.Failure("spreadAttributesParser", newParseError("spread attributes must end with '...'", end, end))
+	}
+	expression = strings.TrimRight(strings.TrimSuffix(expression, "..."), " \t\r\n")
+
+	// Re-read the expression to find where it ends, without the "...".
+	rewind(pi, from.Index)
+	for range expression {
+		if _, err := pi.Advance(); err != nil {
+			return parse.Failure("spreadAttributesParser", err)
+		}
+	}
+	r.Expression = NewExpression(expression, from, NewPositionFromInput(pi))
+	for pi.Index() < end.Index {
+		if _, err := pi.Advance(); err != nil {
+			return parse.Failure("spreadAttributesParser", err)
+		}
+	}
+
+	// Eat the closing brace.
+	if pr = closeBraceWithOptionalPadding(pi); !pr.Success {
+		return pr
+	}
+
+	return parse.Success("spreadAttributesParser", r, nil)
+}
+
 func rewind(pi parse.Input, to int64) error {
 	for i := pi.Index(); i > to; i-- {
 		if _, err := pi.Retreat(); err != nil {
@@ -265,22 +415,64 @@ func (p attributesParser) asAttributeArray(parts []interface{}) (result interfac
 			op[i] = v
 		case ExpressionAttribute:
 			op[i] = v
+		case SpreadAttributes:
+			op[i] = v
+		case ConditionalAttribute:
+			op[i] = v
 		}
 	}
 	return op, true
 }
 
 var attributeParser = parse.Any(
+	newConditionalAttributeParser().Parse,
 	newBoolConstantAttributeParser().Parse,
 	newConstantAttributeParser().Parse,
 	newBoolExpressionAttributeParser().Parse,
 	newExpressionAttributeParser().Parse,
+	newSpreadAttributesParser().Parse,
 )
 
 func (p attributesParser) Parse(pi parse.Input) parse.Result {
 	return parse.Many(p.asAttributeArray, 0, 255, attributeParser)(pi)
 }
 
+// Element attributes.
+type elementAttributes struct {
+	Attributes []Attribute
+	// IndentAttrs is true if any of the attributes start on a new line.
+	IndentAttrs bool
+}
+
+// elementAttributesParser parses attributes in the same way as attributesParser, but also records
+// whether they were written on separate lines, so that the formatter can keep them that way.
+func elementAttributesParser(pi parse.Input) parse.Result {
+	r := elementAttributes{
+		Attributes: []Attribute{},
+	}
+	for len(r.Attributes) < 255 {
+		start := pi.Index()
+		// Peek at the whitespace before the attribute, each attribute parser expects to read it.
+		ws := optionalWhitespaceAsString(pi)
+		rewind(pi, start)
+		pr := attributeParser(pi)
+		// Attributes that are recognised, but invalid, return a ParseError. Other errors mean that the
+		// input isn't an attribute.
+		if _, isParseError := pr.Error.(ParseError); isParseError {
+			return pr
+		}
+		if !pr.Success {
+			rewind(pi, start)
+			break
+		}
+		r.Attributes = append(r.Attributes, pr.Item.(Attribute))
+		if strings.Contains(ws.Item.(string), "\n") {
+			r.IndentAttrs = true
+		}
+	}
+	return parse.Success("elementAttributesParser", r, nil)
+}
+
 // Element name.
 var elementNameFirst = "abcdefghijklmnopqrstuvwxyz"
 var elementNameSubsequent = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-"
@@ -309,6 +501,7 @@ func (p elementOpenCloseParser) Parse(pi parse.Input) parse.Result {
 	ot := otr.Item.(elementOpenTag)
 	r.Name = ot.Name
 	r.Attributes = ot.Attributes
+	r.IndentAttrs = ot.IndentAttrs
 
 	// Once we've got an open tag, the rest must be present.
 	from := NewPositionFromInput(pi)
@@ -343,9 +536,11 @@ type elementSelfClosingParser struct {
 }
 
 func (p elementSelfClosingParser) asElement(parts []interface{}) (result interface{}, ok bool) {
+	attrs := parts[2].(elementAttributes)
 	return Element{
-		Name:       parts[1].(string),
-		Attributes: parts[2].([]Attribute),
+		Name:        parts[1].(string),
+		Attributes:  attrs.Attributes,
+		IndentAttrs: attrs.IndentAttrs,
 	}, true
 }
 
@@ -353,7 +548,7 @@ func (p elementSelfClosingParser) Parse(pi parse.Input) parse.Result {
 	return parse.All(p.asElement,
 		parse.Rune('<'),
 		elementNameParser,
-		newAttributesParser().Parse,
+		elementAttributesParser,
 		optionalWhitespaceParser,
 		parse.String("/>"),
 	)(pi)
@@ -3,6 +3,7 @@ package parser
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/a-h/lexical/input"
 	"github.com/a-h/lexical/parse"
@@ -139,6 +140,96 @@ func TestAttributeParser(t *testing.T) {
 				Value: "value",
 			},
 		},
+		{
+			name:   "attribute name with Alpine.js event shorthand",
+			input:  ` @click="open = !open"`,
+			parser: newConstantAttributeParser().Parse,
+			expected: ConstantAttribute{
+				Name:  "@click",
+				Value: "open = !open",
+			},
+		},
+		{
+			name:   "attribute name with Vue.js bind shorthand",
+			input:  ` :href="url"`,
+			parser: newConstantAttributeParser().Parse,
+			expected: ConstantAttribute{
+				Name:  ":href",
+				Value: "url",
+			},
+		},
+		{
+			name:   "attribute name with colons and modifiers",
+			input:  ` x-on:keyup.enter="submit"`,
+			parser: newConstantAttributeParser().Parse,
+			expected: ConstantAttribute{
+				Name:  "x-on:keyup.enter",
+				Value: "submit",
+			},
+		},
+		{
+			name:   "attribute name with htmx double colon",
+			input:  ` hx-on::after-request="this.reset()"`,
+			parser: newConstantAttributeParser().Parse,
+			expected: ConstantAttribute{
+				Name:  "hx-on::after-request",
+				Value: "this.reset()",
+			},
+		},
+		{
+			name:   "attribute name with namespace",
+			input:  ` xml:lang="en"`,
+			parser: newConstantAttributeParser().Parse,
+			expected: ConstantAttribute{
+				Name:  "xml:lang",
+				Value: "en",
+			},
+		},
+		{
+			name:   "hyperscript attribute name",
+			input:  ` _="on click toggle .red"`,
+			parser: newConstantAttributeParser().Parse,
+			expected: ConstantAttribute{
+				Name:  "_",
+				Value: "on click toggle .red",
+			},
+		},
+		{
+			name:   "boolean attribute followed by the end of the tag",
+			input:  `<div x-cloak>`,
+			parser: newElementOpenTagParser().Parse,
+			expected: elementOpenTag{
+				Name: "div",
+				Attributes: []Attribute{
+					BoolConstantAttribute{
+						Name: "x-cloak",
+					},
+				},
+			},
+		},
+		{
+			name:   "expression attribute with Vue.js bind shorthand",
+			input:  ` :class={ classes }`,
+			parser: attributeParser,
+			expected: ExpressionAttribute{
+				Name: ":class",
+				Expression: Expression{
+					Value: "classes",
+					Range: Range{
+						From: Position{
+							Index: 10,
+							Line:  0,
+							Col:   10,
+						},
+						To: Position{
+							Index: 17,
+							Line:  0,
+							Col:   17,
+						},
+					},
+				},
+			},
+		},
 		{
 			name:   "empty attribute",
 			input:  ` data=""`,
@@ -148,6 +239,136 @@ func TestAttributeParser(t *testing.T) {
 				Value: "",
 			},
 		},
+		{
+			name:   "spread attributes",
+			input:  ` { spread... }`,
+			parser: attributeParser,
+			expected: SpreadAttributes{
+				Expression: Expression{
+					Value: "spread",
+					Range: Range{
+						From: Position{
+							Index: 3,
+							Line:  0,
+							Col:   3,
+						},
+						To: Position{
+							Index: 9,
+							Line:  0,
+							Col:   9,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "spread attributes without spaces",
+			input:  ` {p.Attributes()...}`,
+			parser: attributeParser,
+			expected: SpreadAttributes{
+				Expression: Expression{
+					Value: "p.Attributes()",
+					Range: Range{
+						From: Position{
+							Index: 2,
+							Line:  0,
+							Col:   2,
+						},
+						To: Position{
+							Index: 16,
+							Line:  0,
+							Col:   16,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "spread attributes with braces and spaces",
+			input:  ` { map[string]any{"a": "}"} ...  }`,
+			parser: attributeParser,
+			expected: SpreadAttributes{
+				Expression: Expression{
+					Value: `map[string]any{"a": "}"}`,
+					Range: Range{
+						From: Position{
+							Index: 3,
+							Line:  0,
+							Col:   3,
+						},
+						To: Position{
+							Index: 27,
+							Line:  0,
+							Col:   27,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "conditional attributes",
+			input:  ` if p.Active { class="active" disabled }`,
+			parser: attributeParser,
+			expected: ConditionalAttribute{
+				Expression: Expression{
+					Value: "p.Active",
+					Range: Range{
+						From: Position{
+							Index: 4,
+							Line:  0,
+							Col:   4,
+						},
+						To: Position{
+							Index: 12,
+							Line:  0,
+							Col:   12,
+						},
+					},
+				},
+				Then: []Attribute{
+					ConstantAttribute{
+						Name:  "class",
+						Value: "active",
+					},
+					BoolConstantAttribute{
+						Name: "disabled",
+					},
+				},
+			},
+		},
+		{
+			name:   "conditional attributes with else",
+			input:  ` if p.Active { aria-current="page" } else { hidden }`,
+			parser: attributeParser,
+			expected: ConditionalAttribute{
+				Expression: Expression{
+					Value: "p.Active",
+					Range: Range{
+						From: Position{
+							Index: 4,
+							Line:  0,
+							Col:   4,
+						},
+						To: Position{
+							Index: 12,
+							Line:  0,
+							Col:   12,
+						},
+					},
+				},
+				Then: []Attribute{
+					ConstantAttribute{
+						Name:  "aria-current",
+						Value: "page",
+					},
+				},
+				Else: []Attribute{
+					BoolConstantAttribute{
+						Name: "hidden",
+					},
+				},
+			},
+		},
 		{
 			name:   "attribute containing escaped text",
 			input:  ` href="&lt;&quot;&gt;"`,
@@ -557,6 +778,111 @@ func TestElementParserErrors(t *testing.T) {
 					Col:   20,
 				}),
 		},
+		{
+			name:  "element: attempted use of expression for style attribute (conditional)",
+			input: `<a if p.Styled { style={ value } }/>`,
+			expected: newParseError(`<a>: invalid style attribute: style attributes cannot be a templ expression`,
+				Position{
+					Index: 0,
+					Line:  0,
+					Col:   0,
+				},
+				Position{
+					Index: 36,
+					Line:  0,
+					Col:   36,
+				}),
+		},
+		{
+			name:  "element: attempted use of expression for bound style attribute",
+			input: `<a x-bind:style={ value }/>`,
+			expected: newParseError(`<a>: invalid style attribute: style attributes cannot be a templ expression`,
+				Position{
+					Index: 0,
+					Line:  0,
+					Col:   0,
+				},
+				Position{
+					Index: 27,
+					Line:  0,
+					Col:   27,
+				}),
+		},
+		{
+			name:  "element: conditional attributes without whitespace before the attribute",
+			input: `<input if x {disabled}/>`,
+			expected: newParseError("if: missing end (expected '}')",
+				Position{
+					Index: 13,
+					Line:  0,
+					Col:   13,
+				},
+				Position{
+					Index: 13,
+					Line:  0,
+					Col:   13,
+				}),
+		},
+		{
+			name:  "element: unterminated conditional attributes",
+			input: `<input if x { class="a"/>`,
+			expected: newParseError("if: missing end (expected '}')",
+				Position{
+					Index: 13,
+					Line:  0,
+					Col:   13,
+				},
+				Position{
+					Index: 23,
+					Line:  0,
+					Col:   23,
+				}),
+		},
+		{
+			name:  "element: spread attributes without the ...",
+			input: `<input { attrs }/>`,
+			expected: newParseError("spread attributes must end with '...'",
+				Position{
+					Index: 14,
+					Line:  0,
+					Col:   14,
+				},
+				Position{
+					Index: 14,
+					Line:  0,
+					Col:   14,
+				}),
+		},
+		{
+			name:  "element: spread attributes without the ..., followed by spread attributes",
+			input: `<div><a { x }>x</a><b { y... }>y</b></div>`,
+			expected: newParseError("spread attributes must end with '...'",
+				Position{
+					Index: 11,
+					Line:  0,
+					Col:   11,
+				},
+				Position{
+					Index: 11,
+					Line:  0,
+					Col:   11,
+				}),
+		},
+		{
+			name:  "element: unterminated spread attributes",
+			input: `<input { attrs.../>`,
+			expected: newParseError("spread attributes: unterminated (missing closing '}')",
+				Position{
+					Index: 9,
+					Line:  0,
+					Col:   9,
+				},
+				Position{
+					Index: 9,
+					Line:  0,
+					Col:   9,
+				}),
+		},
 		{
 			name:  "element: script tags cannot contain non-text nodes",
 			input: `<script>{ "value" }</script>`,
@@ -591,8 +917,10 @@ func TestElementParserErrors(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			input := input.NewFromString(tt.input)
-			result := element.Parse(input)
+			var result parse.Result
+			withTimeout(t, func() {
+				result = element.Parse(input.NewFromString(tt.input))
+			})
 			if diff := cmp.Diff(tt.expected, result.Error); diff != "" {
 				t.Errorf(diff)
 			}
@@ -600,6 +928,22 @@ func TestElementParserErrors(t *testing.T) {
 	}
 }
 
+// withTimeout fails the test if f doesn't return, since invalid input has caused the parser to
+// loop forever.
+func withTimeout(t *testing.T, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+}
+
 func TestBigElement(t *testing.T) {
 	var sb strings.Builder
 	sb.WriteString("<div>")
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"go/format"
+	"strings"
+)
+
+// The Go code within templates is formatted by wrapping it in enough Go to make a valid file, running
+// go/format, and removing the wrapper. If the code can't be formatted, e.g. because it doesn't
+// compile, it's left as it was written.
+
+// formatGoCode formats top-level Go code, e.g. imports, types and functions.
+func formatGoCode(code string) string {
+	return formatGoFragment("package p\n\n", code, "\n", true)
+}
+
+// formatGoExpression formats a Go expression, e.g. the contents of { ... }.
+func formatGoExpression(expression string) string {
+	return formatGoFragment("package p\n\nvar _ = ", expression, "\n", false)
+}
+
+// formatTemplateSignature formats the name and parameters of a template, e.g. Name(a, b string).
+func formatTemplateSignature(signature string) string {
+	return formatGoFragment("package p\n\nfunc ", signature, " {\n}\n", false)
+}
+
+// formatStatementHeader formats the part of an if, for or switch statement between the keyword
+// and the opening brace, e.g. the "i := 0; i < 10; i++" of "for i := 0; i < 10; i++ {".
+func formatStatementHeader(keyword, header string) string {
+	return formatGoFragment("package p\n\nfunc _() {\n\t"+keyword+" ", header, " {\n\t}\n}\n", false)
+}
+
+// formatCase formats a case clause of a switch statement, e.g. "case 1, 2:".
+func formatCase(clause string) string {
+	return formatGoFragment("package p\n\nfunc _() {\n\tswitch {\n\t", clause, "\n\t}\n}\n", false)
+}
+
+// formatGoFragment formats the fragment of Go code, which must be formatted to exactly the prefix
+// and suffix once it's wrapped with them. Unless multiline is set, formatted fragments that span
+// multiple lines are left as they were, since they would need to be re-indented.
+func formatGoFragment(prefix, fragment, suffix string, multiline bool) string {
+	trimmed := strings.TrimSpace(fragment)
+	if trimmed == "" {
+		return fragment
+	}
+	formatted, err := format.Source([]byte(prefix + trimmed + suffix))
+	if err != nil {
+		return fragment
+	}
+	s := string(formatted)
+	if !strings.HasPrefix(s, prefix) || !strings.HasSuffix(s, suffix) || len(s) < len(prefix)+len(suffix) {
+		return fragment
+	}
+	s = s[len(prefix) : len(s)-len(suffix)]
+	if !multiline && strings.Contains(s, "\n") {
+		return fragment
+	}
+	return s
+}
@@ -0,0 +1,77 @@
+package parser
+
+import "testing"
+
+func TestGoFormatting(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   func(string) string
+		input    string
+		expected string
+	}{
+		{
+			name:     "expressions are formatted",
+			format:   formatGoExpression,
+			input:    "  add(a,b)+1 ",
+			expected: "add(a, b) + 1",
+		},
+		{
+			name:     "expressions that can't be parsed are left as they are",
+			format:   formatGoExpression,
+			input:    "add(a,",
+			expected: "add(a,",
+		},
+		{
+			name:     "expressions that would be formatted over multiple lines are left as they are",
+			format:   formatGoExpression,
+			input:    "[]string{\n  \"a\",\n}",
+			expected: "[]string{\n  \"a\",\n}",
+		},
+		{
+			name:     "template signatures are formatted like function signatures",
+			format:   formatTemplateSignature,
+			input:    "Name(a,b string, c   int)",
+			expected: "Name(a, b string, c int)",
+		},
+		{
+			name:     "template signatures can have type parameters",
+			format:   formatTemplateSignature,
+			input:    "List[T  any](items []T)",
+			expected: "List[T any](items []T)",
+		},
+		{
+			name:     "top-level Go code is formatted like a Go file",
+			format:   formatGoCode,
+			input:    "import \"fmt\"\nfunc   hello()  { fmt.Println( \"hello\") }",
+			expected: "import \"fmt\"\n\nfunc hello() { fmt.Println(\"hello\") }",
+		},
+		{
+			name:     "top-level Go code that can't be parsed is left as it is",
+			format:   formatGoCode,
+			input:    "func hello() {",
+			expected: "func hello() {",
+		},
+		{
+			name: "statement headers are formatted",
+			format: func(s string) string {
+				return formatStatementHeader("for", s)
+			},
+			input:    "i:=0;i<10;i++",
+			expected: "i := 0; i < 10; i++",
+		},
+		{
+			name:     "case clauses are formatted",
+			format:   formatCase,
+			input:    "case  1,2:\n",
+			expected: "case 1, 2:",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := tt.format(tt.input); actual != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -66,6 +66,18 @@ func (p ifExpressionParser) Parse(pi parse.Input) parse.Result {
 	}
 	r.Then = pr.Item.([]Node)
 
+	// Read the optional 'ElseIf' expressions.
+	for {
+		pr = elseIfExpression.Parse(pi)
+		if pr.Error != nil && pr.Error != io.EOF {
+			return pr
+		}
+		if !pr.Success {
+			break
+		}
+		r.ElseIfs = append(r.ElseIfs, pr.Item.(ElseIfExpression))
+	}
+
 	// Read the optional 'Else' Nodes.
 	from = NewPositionFromInput(pi)
 	pr = parse.Optional(p.asChildren, elseExpression.Parse)(pi)
@@ -82,6 +94,66 @@ func (p ifExpressionParser) Parse(pi parse.Input) parse.Result {
 	return parse.Success("if", r, nil)
 }
 
+var elseIfExpression elseIfExpressionParser
+
+type elseIfExpressionParser struct {
+}
+
+var elseIfExpressionStartParser = parse.All(parse.WithStringConcatCombiner,
+	parse.Rune('}'),
+	optionalWhitespaceParser,
+	parse.String("else"),
+	whitespaceParser,
+	parse.String("if"),
+	whitespaceParser)
+
+func (p elseIfExpressionParser) Parse(pi parse.Input) parse.Result {
+	var r ElseIfExpression
+
+	// Check the prefix first.
+	prefixResult := elseIfExpressionStartParser(pi)
+	if !prefixResult.Success {
+		return prefixResult
+	}
+
+	// Once we've got a prefix, read until {\n.
+	from := NewPositionFromInput(pi)
+	pr := parse.StringUntil(parse.All(parse.WithStringConcatCombiner, openBraceWithOptionalPadding, newLine))(pi)
+	if pr.Error != nil && pr.Error != io.EOF {
+		return pr
+	}
+	// If there's no match, there's no {\n, which is an error.
+	if !pr.Success {
+		return parse.Failure("elseIfExpressionParser", newParseError("else if: unterminated (missing closing '{\n')", from, NewPositionFromInput(pi)))
+	}
+	r.Expression = NewExpression(pr.Item.(string), from, NewPositionFromInput(pi))
+
+	// Eat " {".
+	from = NewPositionFromInput(pi)
+	if te := expressionEnd(pi); !te.Success {
+		return parse.Failure("elseIfExpressionParser", newParseError("else if: unterminated (missing closing '{')", from, NewPositionFromInput(pi)))
+	}
+
+	// Eat required newline.
+	if lb := newLine(pi); lb.Error != nil {
+		return lb
+	}
+
+	// Read the 'Then' nodes, up to the next else if, else, or the end of the if expression.
+	from = NewPositionFromInput(pi)
+	pr = newTemplateNodeParser(closeBraceWithOptionalPadding).Parse(pi)
+	if pr.Error != nil && pr.Error != io.EOF {
+		return pr
+	}
+	// If there's no match, there's a problem in the template nodes.
+	if !pr.Success {
+		return parse.Failure("elseIfExpressionParser", newParseError("else if: expected nodes, but none were found", from, NewPositionFromInput(pi)))
+	}
+	r.Then = pr.Item.([]Node)
+
+	return parse.Success("else if", r, nil)
+}
+
 var elseExpression elseExpressionParser
 
 type elseExpressionParser struct {
@@ -27,7 +27,7 @@ func (p rawElementParser) Parse(pi parse.Input) parse.Result {
 	otr := parse.All(asElementOpenTag,
 		parse.Rune('<'),
 		parse.String(p.name),
-		newAttributesParser().Parse,
+		elementAttributesParser,
 		parse.Optional(parse.WithStringConcatCombiner, whitespaceParser),
 		parse.Rune('>'),
 	)(pi)
@@ -17,6 +17,21 @@ func TestTemplateFileParser(t *testing.T) {
 			t.Errorf("expected ErrLegacyFileFormat, got %v", err)
 		}
 	})
+	t.Run("returns errors in attributes", func(t *testing.T) {
+		input := `package test
+
+templ a() {
+	<input if x { class="a"/>
+}
+`
+		var err error
+		withTimeout(t, func() {
+			_, err = ParseString(input)
+		})
+		if _, isParseError := err.(ParseError); !isParseError {
+			t.Errorf("expected a ParseError, got %v", err)
+		}
+	})
 	t.Run("does not require a package expression", func(t *testing.T) {
 		input := `templ Hello() {
 Hello
@@ -99,6 +99,17 @@ func (p templateNodeParser) Parse(pi parse.Input) parse.Result {
 			}
 		}
 
+		// Try for a comment.
+		// <!-- Comment --> or {/* Comment */}
+		pr = newCommentParser().Parse(pi)
+		if pr.Error != nil {
+			return pr
+		}
+		if pr.Success {
+			op = append(op, pr.Item.(Node))
+			continue
+		}
+
 		// Try for a doctype.
 		// <!DOCTYPE html>
 		pr = newDocTypeParser().Parse(pi)
@@ -392,6 +392,67 @@ func TestTemplateParser(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "template: comments",
+			input: `templ Name() {
+<!-- HTML comment -->
+{/* templ comment */}
+<div>{ "text" }</div>
+}`,
+			expected: HTMLTemplate{
+				Expression: Expression{
+					Value: "Name()",
+					Range: Range{
+						From: Position{
+							Index: 6,
+							Line:  0,
+							Col:   6,
+						},
+						To: Position{
+							Index: 12,
+							Line:  0,
+							Col:   12,
+						},
+					},
+				},
+				Children: []Node{
+					Comment{
+						Contents: " HTML comment ",
+						Type:     CommentTypeHTML,
+					},
+					Whitespace{Value: "\n"},
+					Comment{
+						Contents: " templ comment ",
+						Type:     CommentTypeTempl,
+					},
+					Whitespace{Value: "\n"},
+					Element{
+						Name:       "div",
+						Attributes: []Attribute{},
+						Children: []Node{
+							StringExpression{
+								Expression: Expression{
+									Value: `"text"`,
+									Range: Range{
+										From: Position{
+											Index: 66,
+											Line:  3,
+											Col:   7,
+										},
+										To: Position{
+											Index: 72,
+											Line:  3,
+											Col:   13,
+										},
+									},
+								},
+							},
+						},
+					},
+					Whitespace{Value: "\n"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/a-h/lexical/parse"
+	"github.com/a-h/templ"
 )
 
 // package parser
@@ -151,7 +152,7 @@ type GoExpression struct {
 
 func (exp GoExpression) IsTemplateFileNode() bool { return true }
 func (exp GoExpression) Write(w io.Writer, indent int) error {
-	return writeIndent(w, indent, exp.Expression.Value)
+	return writeIndent(w, indent, formatGoCode(exp.Expression.Value))
 }
 
 func writeIndent(w io.Writer, level int, s string) (err error) {
@@ -258,6 +259,32 @@ func (dt DocType) Write(w io.Writer, indent int) error {
 	return writeIndent(w, indent, "<!DOCTYPE "+dt.Value+">")
 }
 
+// CommentType is the type of a Comment node.
+type CommentType int
+
+const (
+	// CommentTypeHTML is a HTML comment, which is rendered to the output.
+	// <!-- Comment -->
+	CommentTypeHTML CommentType = iota
+	// CommentTypeTempl is a templ comment, which is removed from the output.
+	// {/* Comment */}
+	CommentTypeTempl
+)
+
+// <!-- Comment --> or {/* Comment */}
+type Comment struct {
+	Contents string
+	Type     CommentType
+}
+
+func (c Comment) IsNode() bool { return true }
+func (c Comment) Write(w io.Writer, indent int) error {
+	if c.Type == CommentTypeTempl {
+		return writeIndent(w, indent, "{/*"+c.Contents+"*/}")
+	}
+	return writeIndent(w, indent, "<!--"+c.Contents+"-->")
+}
+
 // HTMLTemplate definition.
 // templ Name(p Parameter) {
 //   if ... {
@@ -272,7 +299,7 @@ type HTMLTemplate struct {
 func (t HTMLTemplate) IsTemplateFileNode() bool { return true }
 
 func (t HTMLTemplate) Write(w io.Writer, indent int) error {
-	if err := writeIndent(w, indent, "templ "+t.Expression.Value+" {\n"); err != nil {
+	if err := writeIndent(w, indent, "templ "+formatTemplateSignature(t.Expression.Value)+" {\n"); err != nil {
 		return err
 	}
 	if err := writeNodesBlock(w, indent+1, t.Children); err != nil {
@@ -306,7 +333,9 @@ func (t Text) Write(w io.Writer, indent int) error {
 type Element struct {
 	Name       string
 	Attributes []Attribute
-	Children   []Node
+	// IndentAttrs is true if the attributes were written on separate lines.
+	IndentAttrs bool
+	Children    []Node
 }
 
 var voidElements = map[string]struct{}{
@@ -350,6 +379,8 @@ func (e Element) containsBlockElement() bool {
 			continue
 		case Text:
 			continue
+		case Comment:
+			continue
 		}
 		// Any template elements should be considered block.
 		return true
@@ -360,12 +391,8 @@ func (e Element) containsBlockElement() bool {
 // Validate that no invalid expressions have been used.
 func (e Element) Validate() (msgs []string, ok bool) {
 	// Validate that style attributes are constant.
-	for _, attr := range e.Attributes {
-		if exprAttr, isExprAttr := attr.(ExpressionAttribute); isExprAttr {
-			if strings.EqualFold(exprAttr.Name, "style") {
-				msgs = append(msgs, "invalid style attribute: style attributes cannot be a templ expression")
-			}
-		}
+	if containsExpressionStyleAttribute(e.Attributes) {
+		msgs = append(msgs, "invalid style attribute: style attributes cannot be a templ expression")
 	}
 	// Validate that script and style tags don't contain expressions.
 	if strings.EqualFold(e.Name, "script") || strings.EqualFold(e.Name, "style") {
@@ -376,6 +403,24 @@ func (e Element) Validate() (msgs []string, ok bool) {
 	return msgs, len(msgs) == 0
 }
 
+// containsExpressionStyleAttribute returns true if an expression is used for an attribute that
+// contains CSS, including bindings such as :style.
+func containsExpressionStyleAttribute(attrs []Attribute) bool {
+	for _, attr := range attrs {
+		switch a := attr.(type) {
+		case ExpressionAttribute:
+			if templ.AttributeContextOf(a.Name) == templ.AttributeContextCSS {
+				return true
+			}
+		case ConditionalAttribute:
+			if containsExpressionStyleAttribute(a.Then) || containsExpressionStyleAttribute(a.Else) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func containsNonTextNodes(nodes []Node) bool {
 	for i := 0; i < len(nodes); i++ {
 		n := nodes[i]
@@ -391,22 +436,45 @@ func containsNonTextNodes(nodes []Node) bool {
 	return false
 }
 
+// startsOnNewLine returns true if the children of the element start on a new line after the
+// opening tag, in which case the author chose a block layout.
+func (e Element) startsOnNewLine() bool {
+	if len(e.Children) == 0 {
+		return false
+	}
+	ws, isWhitespace := e.Children[0].(Whitespace)
+	return isWhitespace && strings.Contains(ws.Value, "\n")
+}
+
 func (e Element) IsNode() bool { return true }
 func (e Element) Write(w io.Writer, indent int) error {
 	if err := writeIndent(w, indent, "<"+e.Name); err != nil {
 		return err
 	}
 	for i := 0; i < len(e.Attributes); i++ {
-		if _, err := w.Write([]byte(" ")); err != nil {
+		a := e.Attributes[i]
+		if e.IndentAttrs {
+			// Keep attributes that were written on separate lines that way.
+			if _, err := w.Write([]byte("\n")); err != nil {
+				return err
+			}
+			if err := writeIndent(w, indent+1, a.String()); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := w.Write([]byte(" " + a.String())); err != nil {
 			return err
 		}
-		a := e.Attributes[i]
-		if _, err := w.Write([]byte(a.String())); err != nil {
+	}
+	if e.IndentAttrs && len(e.Attributes) > 0 {
+		// The end of the opening tag goes on its own line.
+		if _, err := w.Write([]byte("\n" + strings.Repeat("\t", indent))); err != nil {
 			return err
 		}
 	}
 	if e.hasNonWhitespaceChildren() {
-		if e.containsBlockElement() {
+		if e.containsBlockElement() || e.startsOnNewLine() {
 			if _, err := w.Write([]byte(">\n")); err != nil {
 				return err
 			}
@@ -421,7 +489,7 @@ func (e Element) Write(w io.Writer, indent int) error {
 		if _, err := w.Write([]byte(">")); err != nil {
 			return err
 		}
-		if err := writeNodesInline(w, e.Children); err != nil {
+		if err := writeNodesInline(w, indent, e.Children); err != nil {
 			return err
 		}
 		if _, err := w.Write([]byte("</" + e.Name + ">")); err != nil {
@@ -441,31 +509,99 @@ func (e Element) Write(w io.Writer, indent int) error {
 	return nil
 }
 
-func writeNodesInline(w io.Writer, nodes []Node) error {
-	return writeNodes(w, 0, nodes, false)
+// writeNodesInline writes the nodes on a single line. Whitespace between the nodes is collapsed to a
+// single space, and leading and trailing whitespace is removed.
+func writeNodesInline(w io.Writer, indent int, nodes []Node) error {
+	var prev Node
+	var ws string
+	for _, n := range nodes {
+		if v, isWhitespace := n.(Whitespace); isWhitespace {
+			ws += v.Value
+			continue
+		}
+		if prev != nil && ws != "" {
+			if _, err := w.Write([]byte(" ")); err != nil {
+				return err
+			}
+		}
+		if err := writeNodeInline(w, indent, n); err != nil {
+			return err
+		}
+		prev, ws = n, ""
+	}
+	return nil
 }
 
+// writeNodesBlock writes each node on a new line. Inline nodes that were written on the same line,
+// such as text and inline elements, are kept on the same line, and up to one blank line is kept
+// between nodes.
 func writeNodesBlock(w io.Writer, indent int, nodes []Node) error {
-	return writeNodes(w, indent, nodes, true)
-}
-
-func writeNodes(w io.Writer, indent int, nodes []Node, block bool) error {
-	for i := 0; i < len(nodes); i++ {
-		if _, isWhitespace := nodes[i].(Whitespace); isWhitespace {
+	var prev Node
+	var ws string
+	for _, n := range nodes {
+		if v, isWhitespace := n.(Whitespace); isWhitespace {
+			ws += v.Value
 			continue
 		}
-		if err := nodes[i].Write(w, indent); err != nil {
-			return err
-		}
-		if block {
-			if _, err := w.Write([]byte("\n")); err != nil {
+		newLines := strings.Count(ws, "\n")
+		switch {
+		case prev == nil:
+			if err := n.Write(w, indent); err != nil {
+				return err
+			}
+		case newLines == 0 && isInlineNode(prev) && isInlineNode(n):
+			if ws != "" {
+				if _, err := w.Write([]byte(" ")); err != nil {
+					return err
+				}
+			}
+			if err := writeNodeInline(w, indent, n); err != nil {
+				return err
+			}
+		default:
+			sep := "\n"
+			if newLines > 1 {
+				sep = "\n\n"
+			}
+			if _, err := w.Write([]byte(sep)); err != nil {
+				return err
+			}
+			if err := n.Write(w, indent); err != nil {
 				return err
 			}
 		}
+		prev, ws = n, ""
+	}
+	if prev != nil {
+		if _, err := w.Write([]byte("\n")); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// writeNodeInline writes the node without indenting its first line, so that it can follow other
+// nodes on the same line. Any following lines are indented as usual.
+func writeNodeInline(w io.Writer, indent int, n Node) error {
+	var sb strings.Builder
+	if err := n.Write(&sb, indent); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, strings.TrimPrefix(sb.String(), strings.Repeat("\t", indent)))
+	return err
+}
+
+// isInlineNode returns true if the node can share a line with other nodes.
+func isInlineNode(n Node) bool {
+	switch n := n.(type) {
+	case Text, StringExpression, Comment:
+		return true
+	case Element:
+		return !n.isBlockElement()
+	}
+	return false
+}
+
 type RawElement struct {
 	Name       string
 	Attributes []Attribute
@@ -487,7 +623,7 @@ func (e RawElement) Write(w io.Writer, indent int) error {
 			return err
 		}
 	}
-	if _, err := w.Write([]byte("/>")); err != nil {
+	if _, err := w.Write([]byte(">")); err != nil {
 		return err
 	}
 	// Contents.
@@ -535,7 +671,7 @@ type BoolExpressionAttribute struct {
 
 func (ea BoolExpressionAttribute) IsAttribute() bool { return true }
 func (ea BoolExpressionAttribute) String() string {
-	return ea.Name + `?={ ` + ea.Expression.Value + ` }`
+	return ea.Name + `?={ ` + formatGoExpression(ea.Expression.Value) + ` }`
 }
 
 // href={ ... }
@@ -546,7 +682,45 @@ type ExpressionAttribute struct {
 
 func (ea ExpressionAttribute) IsAttribute() bool { return true }
 func (ea ExpressionAttribute) String() string {
-	return ea.Name + `={ ` + ea.Expression.Value + ` }`
+	return ea.Name + `={ ` + formatGoExpression(ea.Expression.Value) + ` }`
+}
+
+// if p.Active { class="active" aria-current="page" } else { class="inactive" }
+type ConditionalAttribute struct {
+	Expression Expression
+	Then       []Attribute
+	Else       []Attribute
+}
+
+func (ca ConditionalAttribute) IsAttribute() bool { return true }
+func (ca ConditionalAttribute) String() string {
+	var sb strings.Builder
+	sb.WriteString("if " + formatStatementHeader("if", ca.Expression.Value) + " {")
+	writeAttributesInline(&sb, ca.Then)
+	sb.WriteString(" }")
+	if len(ca.Else) > 0 {
+		sb.WriteString(" else {")
+		writeAttributesInline(&sb, ca.Else)
+		sb.WriteString(" }")
+	}
+	return sb.String()
+}
+
+func writeAttributesInline(sb *strings.Builder, attrs []Attribute) {
+	for _, a := range attrs {
+		sb.WriteString(" ")
+		sb.WriteString(a.String())
+	}
+}
+
+// { attrs... }
+type SpreadAttributes struct {
+	Expression Expression
+}
+
+func (sa SpreadAttributes) IsAttribute() bool { return true }
+func (sa SpreadAttributes) String() string {
+	return `{ ` + formatGoExpression(sa.Expression.Value) + `... }`
 }
 
 // Nodes.
@@ -562,7 +736,7 @@ type CallTemplateExpression struct {
 
 func (cte CallTemplateExpression) IsNode() bool { return true }
 func (cte CallTemplateExpression) Write(w io.Writer, indent int) error {
-	return writeIndent(w, indent, `{! `+cte.Expression.Value+` }`)
+	return writeIndent(w, indent, `{! `+formatGoExpression(cte.Expression.Value)+` }`)
 }
 
 // TemplElementExpression can be used to create and render a template using data.
@@ -579,9 +753,9 @@ type TemplElementExpression struct {
 func (tee TemplElementExpression) IsNode() bool { return true }
 func (tee TemplElementExpression) Write(w io.Writer, indent int) error {
 	if len(tee.Children) == 0 {
-		return writeIndent(w, indent, fmt.Sprintf("@%s", tee.Expression.Value))
+		return writeIndent(w, indent, fmt.Sprintf("@%s", formatGoExpression(tee.Expression.Value)))
 	}
-	if err := writeIndent(w, indent, fmt.Sprintf("@%s {\n", tee.Expression.Value)); err != nil {
+	if err := writeIndent(w, indent, fmt.Sprintf("@%s {\n", formatGoExpression(tee.Expression.Value))); err != nil {
 		return err
 	}
 	if err := writeNodesBlock(w, indent+1, tee.Children); err != nil {
@@ -606,16 +780,25 @@ func (ChildrenExpression) Write(w io.Writer, indent int) error {
 }
 
 // if p.Type == "test" && p.thing {
+// } else if p.Type == "other" {
+// } else {
 // }
 type IfExpression struct {
 	Expression Expression
 	Then       []Node
+	ElseIfs    []ElseIfExpression
 	Else       []Node
 }
 
+// } else if p.Type == "other" {
+type ElseIfExpression struct {
+	Expression Expression
+	Then       []Node
+}
+
 func (n IfExpression) IsNode() bool { return true }
 func (n IfExpression) Write(w io.Writer, indent int) error {
-	if err := writeIndent(w, indent, "if "+n.Expression.Value+" {\n"); err != nil {
+	if err := writeIndent(w, indent, "if "+formatStatementHeader("if", n.Expression.Value)+" {\n"); err != nil {
 		return err
 	}
 	indent++
@@ -623,6 +806,14 @@ func (n IfExpression) Write(w io.Writer, indent int) error {
 		return err
 	}
 	indent--
+	for _, elseIf := range n.ElseIfs {
+		if err := writeIndent(w, indent, "} else if "+formatStatementHeader("if", elseIf.Expression.Value)+" {\n"); err != nil {
+			return err
+		}
+		if err := writeNodesBlock(w, indent+1, elseIf.Then); err != nil {
+			return err
+		}
+	}
 	if len(n.Else) > 0 {
 		if err := writeIndent(w, indent, "} else {\n"); err != nil {
 			return err
@@ -647,13 +838,13 @@ type SwitchExpression struct {
 
 func (se SwitchExpression) IsNode() bool { return true }
 func (se SwitchExpression) Write(w io.Writer, indent int) error {
-	if err := writeIndent(w, indent, "switch "+se.Expression.Value+" {\n"); err != nil {
+	if err := writeIndent(w, indent, "switch "+formatStatementHeader("switch", se.Expression.Value)+" {\n"); err != nil {
 		return err
 	}
 	indent++
 	for i := 0; i < len(se.Cases); i++ {
 		c := se.Cases[i]
-		if err := writeIndent(w, indent, c.Expression.Value); err != nil {
+		if err := writeIndent(w, indent, formatCase(c.Expression.Value)+"\n"); err != nil {
 			return err
 		}
 		if err := writeNodesBlock(w, indent+1, c.Children); err != nil {
@@ -683,7 +874,7 @@ type ForExpression struct {
 
 func (fe ForExpression) IsNode() bool { return true }
 func (fe ForExpression) Write(w io.Writer, indent int) error {
-	if err := writeIndent(w, indent, "for "+fe.Expression.Value+" {\n"); err != nil {
+	if err := writeIndent(w, indent, "for "+formatStatementHeader("for", fe.Expression.Value)+" {\n"); err != nil {
 		return err
 	}
 	if err := writeNodesBlock(w, indent+1, fe.Children); err != nil {
@@ -704,7 +895,7 @@ type StringExpression struct {
 func (se StringExpression) IsNode() bool                  { return true }
 func (se StringExpression) IsStyleDeclarationValue() bool { return true }
 func (se StringExpression) Write(w io.Writer, indent int) error {
-	return writeIndent(w, indent, `{ `+se.Expression.Value+` }`)
+	return writeIndent(w, indent, `{ `+formatGoExpression(se.Expression.Value)+` }`)
 }
 
 // ScriptTemplate is a script block.
@@ -716,7 +907,7 @@ type ScriptTemplate struct {
 
 func (s ScriptTemplate) IsTemplateFileNode() bool { return true }
 func (s ScriptTemplate) Write(w io.Writer, indent int) error {
-	if err := writeIndent(w, indent, "script "+s.Name.Value+"("+s.Parameters.Value+") {\n"); err != nil {
+	if err := writeIndent(w, indent, "script "+formatTemplateSignature(s.Name.Value+"("+s.Parameters.Value+")")+" {\n"); err != nil {
 		return err
 	}
 	if _, err := io.WriteString(w, s.Value); err != nil {
@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"io/fs"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -152,6 +155,131 @@ templ input(items []string) {
 	</div>
 }
 
+`,
+		},
+		{
+			name: "else if expressions are placed on the closing brace line",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input(n int) {
+<div>
+if n == 1 {
+<span>{ "one" }</span>
+}   else if n == 2 {
+<span>{ "two" }</span>
+} else {
+<span>{ "many" }</span>
+}
+</div>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input(n int) {
+	<div>
+		if n == 1 {
+			<span>{ "one" }</span>
+		} else if n == 2 {
+			<span>{ "two" }</span>
+		} else {
+			<span>{ "many" }</span>
+		}
+	</div>
+}
+
+`,
+		},
+		{
+			name: "spread attributes are formatted with spaces",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input(attrs templ.Attributes) {
+	<input type="text"   {attrs...}/>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input(attrs templ.Attributes) {
+	<input type="text" { attrs... }/>
+}
+
+`,
+		},
+		{
+			name: "comments are preserved",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ comments() {
+	<!-- HTML comment -->
+	{ /* templ comment */ }
+<div><p>Text <!-- inline comment --></p>
+		<!--
+			multiline
+		-->
+	</div>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ comments() {
+	<!-- HTML comment -->
+	{/* templ comment */}
+	<div>
+		<p>Text <!-- inline comment --></p>
+		<!--
+			multiline
+		-->
+	</div>
+}
+
+`,
+		},
+		{
+			name: "conditional attributes are formatted with spaces",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ link(active bool) {
+	<a href="/"   if active { class="active"   aria-current="page"}else{ class="inactive" }>Home</a>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ link(active bool) {
+	<a href="/" if active { class="active" aria-current="page" } else { class="inactive" }>Home</a>
+}
+
+`,
+		},
+		{
+			name: "Alpine.js and htmx attribute names are preserved",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ input(classes string) {
+	<div x-data="{ open: false }" x-cloak>
+		<button @click="open = !open" :class={ classes } x-on:keyup.enter="open = false">Toggle</button>
+		<form hx-on::after-request="this.reset()" _="on submit log 'submitted'"></form>
+	</div>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ input(classes string) {
+	<div x-data="{ open: false }" x-cloak>
+		<button @click="open = !open" :class={ classes } x-on:keyup.enter="open = false">Toggle</button>
+		<form hx-on::after-request="this.reset()" _="on submit log &#39;submitted&#39;"></form>
+	</div>
+}
+
 `,
 		},
 		{
@@ -228,6 +356,235 @@ templ table(accountNumber string, registration string) {
 	</table>
 }
 
+`,
+		},
+		{
+			name: "up to one blank line is kept between nodes",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ page() {
+
+	<header>Header</header>
+
+
+	<main>Main</main>
+	<footer>Footer</footer>
+
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ page() {
+	<header>Header</header>
+
+	<main>Main</main>
+	<footer>Footer</footer>
+}
+
+`,
+		},
+		{
+			name: "blank lines are kept within elements and control flow",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ list(items []string) {
+	<ul>
+		<li>First</li>
+
+		for _, item := range items {
+			<li>{ item }</li>
+
+			<li>Separator</li>
+		}
+	</ul>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ list(items []string) {
+	<ul>
+		<li>First</li>
+
+		for _, item := range items {
+			<li>{ item }</li>
+
+			<li>Separator</li>
+		}
+	</ul>
+}
+
+`,
+		},
+		{
+			name: "elements that start on a new line are kept in block layout",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ text(name string) {
+	<p>
+	Hello, <strong>{ name }</strong>.
+	Welcome back.
+	</p>
+	<p>On one line</p>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ text(name string) {
+	<p>
+		Hello, <strong>{ name }</strong>.
+		Welcome back.
+	</p>
+	<p>On one line</p>
+}
+
+`,
+		},
+		{
+			name: "whitespace between inline nodes is collapsed to a single space",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ text(name string) {
+	<p>Hello <b>{ name }</b>   and
+	welcome</p>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ text(name string) {
+	<p>Hello <b>{ name }</b> and welcome</p>
+}
+
+`,
+		},
+		{
+			name: "attributes on separate lines are kept on separate lines",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ form(value string) {
+	<form method="post"
+		action="/submit">
+		<input type="text"   name="value"
+		value={ value }
+		required/>
+	</form>
+	<a   href="/">Home</a>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ form(value string) {
+	<form
+		method="post"
+		action="/submit"
+	>
+		<input
+			type="text"
+			name="value"
+			value={ value }
+			required
+		/>
+	</form>
+	<a href="/">Home</a>
+}
+
+`,
+		},
+		{
+			name: "raw elements are written with their contents",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+templ raw() {
+	<style type="text/css"><!-- Some stuff --></style>
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+templ raw() {
+	<style type="text/css"><!-- Some stuff --></style>
+}
+
+`,
+		},
+		{
+			name: "Go code is formatted with go/format",
+			input: ` // first line removed to make indentation clear in Go code
+package test
+
+import (
+"strings"
+  "fmt"
+)
+
+func   add(a,b int) int { return a+b }
+
+templ list(items   []string,n int, attrs templ.Attributes) {
+	<ul class={ strings.Join(items,  " ") } if n>1 { data-many="true" } { attrs  ... } hidden?={ n==0 }>
+		for i:=0;i<n;i++ {
+			<li>{ fmt.Sprint(add(i,1)) }</li>
+		}
+	</ul>
+	if n>1 {
+		{! item(items[0] ) }
+	} else if n==1 {
+		@item(items[ 0])
+	}
+	switch  n {
+		case 1,2:
+			<p>few</p>
+		default:
+			<p>many</p>
+	}
+}
+
+script onClick(a,b string) {
+	alert(a);
+}
+`,
+			expected: `// first line removed to make indentation clear in Go code
+package test
+
+import (
+	"fmt"
+	"strings"
+)
+
+func add(a, b int) int { return a + b }
+
+templ list(items []string, n int, attrs templ.Attributes) {
+	<ul class={ strings.Join(items, " ") } if n > 1 { data-many="true" } { attrs... } hidden?={ n == 0 }>
+		for i := 0; i < n; i++ {
+			<li>{ fmt.Sprint(add(i, 1)) }</li>
+		}
+	</ul>
+	if n > 1 {
+		{! item(items[0]) }
+	} else if n == 1 {
+		@item(items[0])
+	}
+	switch n {
+		case 1, 2:
+			<p>few</p>
+		default:
+			<p>many</p>
+	}
+}
+
+script onClick(a, b string) {
+	alert(a);
+}
+
 `,
 		},
 	}
@@ -251,6 +608,61 @@ templ table(accountNumber string, registration string) {
 			if diff := cmp.Diff(expected, w.String()); diff != "" {
 				t.Error(diff)
 			}
+
+			// Formatting the output again should not change it.
+			if diff := cmp.Diff(expected, formatTemplate(t, w.String())); diff != "" {
+				t.Errorf("formatting is not idempotent:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormattingIsIdempotent(t *testing.T) {
+	// Format every template in the repository, including the generator tests, which cover each
+	// feature of the language.
+	var fileNames []string
+	err := filepath.WalkDir("../..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != "../.." {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".templ") {
+			fileNames = append(fileNames, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to find templates: %v", err)
+	}
+	if len(fileNames) == 0 {
+		t.Fatal("expected to find templates")
+	}
+	for _, fileName := range fileNames {
+		fileName := fileName
+		t.Run(fileName, func(t *testing.T) {
+			b, err := os.ReadFile(fileName)
+			if err != nil {
+				t.Fatalf("failed to read template: %v", err)
+			}
+			once := formatTemplate(t, string(b))
+			if diff := cmp.Diff(once, formatTemplate(t, once)); diff != "" {
+				t.Errorf("formatting is not idempotent:\n%s", diff)
+			}
 		})
 	}
 }
+
+func formatTemplate(t *testing.T, input string) string {
+	t.Helper()
+	template, err := ParseString(input)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	w := new(strings.Builder)
+	if err = template.Write(w); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	return w.String()
+}
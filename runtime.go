@@ -1,6 +1,7 @@
 package templ
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -11,7 +12,10 @@ import (
 	"net/http"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"unicode"
 
 	"github.com/a-h/templ/safehtml"
 )
@@ -61,24 +65,126 @@ type ComponentHandler struct {
 	Status       int
 	ContentType  string
 	ErrorHandler func(r *http.Request, err error) http.Handler
+	// Buffered renders the component fully before writing the response, so that a failed render
+	// only writes the error response.
+	Buffered bool
+	// Streaming sends the headers before the component is rendered, so that the response is chunked,
+	// and output is sent to the client at each Flush component.
+	Streaming bool
+	// RecoverPanics returns a PanicError to the ErrorHandler if the component panics.
+	RecoverPanics bool
+	// ErrorReporter is called with errors returned by the component, and with errors handled by
+	// ErrorBoundary components.
+	ErrorReporter ErrorReporter
+}
+
+// ErrorReporter reports errors that occur while rendering components, e.g. by logging them or
+// recording metrics.
+type ErrorReporter interface {
+	ReportError(r *http.Request, err error)
+}
+
+// ErrorReporterFunc converts a function into an ErrorReporter.
+type ErrorReporterFunc func(r *http.Request, err error)
+
+// ReportError calls the function.
+func (f ErrorReporterFunc) ReportError(r *http.Request, err error) {
+	f(r, err)
 }
 
 var componentHandlerErrorMessage = "templ: failed to render template"
 
 // ServeHTTP implements the http.Handler interface.
 func (ch *ComponentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if ch.Status != 0 {
+	// Add the Suspense sections to the context, so that they can be rendered after the component.
+	ctx, _ := SuspendedFromContext(r.Context())
+	if _, ok := ctx.Value(contextKeyErrorHook).(func(ctx context.Context, err error)); !ok && ch.ErrorReporter != nil {
+		ctx = WithErrorHook(ctx, func(ctx context.Context, err error) {
+			ch.ErrorReporter.ReportError(r, err)
+		})
+	}
+	r = r.WithContext(ctx)
+	if ch.Buffered {
+		ch.serveBuffered(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", ch.ContentType)
+	if ch.Streaming {
+		w.Header().Del("Content-Length")
+		status := ch.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		w.WriteHeader(status)
+		flush(w)
+	} else if ch.Status != 0 {
 		w.WriteHeader(ch.Status)
 	}
-	w.Header().Add("Content-Type", ch.ContentType)
-	err := ch.Component.Render(r.Context(), w)
+	if err := ch.render(r.Context(), w); err != nil {
+		ch.serveError(w, r, err)
+	}
+}
+
+// render the component, followed by any Suspense sections.
+func (ch *ComponentHandler) render(ctx context.Context, w io.Writer) (err error) {
+	if ch.RecoverPanics {
+		err = renderRecover(ctx, ch.Component, w)
+	} else {
+		err = ch.Component.Render(ctx, w)
+	}
 	if err != nil {
-		if ch.ErrorHandler != nil {
-			ch.ErrorHandler(r, err).ServeHTTP(w, r)
-			return
-		}
-		http.Error(w, componentHandlerErrorMessage, http.StatusInternalServerError)
+		return err
+	}
+	_, s := SuspendedFromContext(ctx)
+	return s.Render(ctx, w)
+}
+
+func (ch *ComponentHandler) serveBuffered(w http.ResponseWriter, r *http.Request) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := ch.render(r.Context(), buf); err != nil {
+		ch.serveError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", ch.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	if ch.Status != 0 {
+		w.WriteHeader(ch.Status)
+	}
+	buf.WriteTo(w)
+}
+
+func (ch *ComponentHandler) serveError(w http.ResponseWriter, r *http.Request, err error) {
+	if ch.ErrorReporter != nil {
+		ch.ErrorReporter.ReportError(r, err)
+	}
+	if ch.ErrorHandler != nil {
+		ch.ErrorHandler(r, err).ServeHTTP(w, r)
+		return
+	}
+	http.Error(w, componentHandlerErrorMessage, http.StatusInternalServerError)
+}
+
+// maxPooledBufferSize is the largest buffer returned to the pool, so that a single large page
+// doesn't keep a large amount of memory allocated.
+const maxPooledBufferSize = 64 * 1024
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
 	}
+	buf.Reset()
+	bufferPool.Put(buf)
 }
 
 // Handler creates a http.Handler that renders the template.
@@ -107,6 +213,40 @@ func WithContentType(contentType string) func(*ComponentHandler) {
 	}
 }
 
+// WithBuffering renders the component fully before writing the response. If rendering fails, only the
+// error response is written. Successful responses set the Content-Length header.
+func WithBuffering() func(*ComponentHandler) {
+	return func(ch *ComponentHandler) {
+		ch.Buffered = true
+		ch.Streaming = false
+	}
+}
+
+// WithStreaming sends the status and headers before the component is rendered, and sends the output
+// rendered so far to the client at each Flush component. If rendering fails, the error is written
+// after the partial output.
+func WithStreaming() func(*ComponentHandler) {
+	return func(ch *ComponentHandler) {
+		ch.Streaming = true
+		ch.Buffered = false
+	}
+}
+
+// WithPanicRecovery recovers from panics while rendering the component, and passes a PanicError to the
+// error handler.
+func WithPanicRecovery() func(*ComponentHandler) {
+	return func(ch *ComponentHandler) {
+		ch.RecoverPanics = true
+	}
+}
+
+// WithErrorReporter sets the reporter that's called with errors that occur while rendering.
+func WithErrorReporter(er ErrorReporter) func(*ComponentHandler) {
+	return func(ch *ComponentHandler) {
+		ch.ErrorReporter = er
+	}
+}
+
 // WithErrorHandler sets the error handler used if rendering fails.
 func WithErrorHandler(eh func(r *http.Request, err error) http.Handler) func(*ComponentHandler) {
 	return func(ch *ComponentHandler) {
@@ -114,6 +254,34 @@ func WithErrorHandler(eh func(r *http.Request, err error) http.Handler) func(*Co
 	}
 }
 
+// Flush sends the output rendered so far to the client, if the writer supports it, e.g. when rendering
+// to a http.ResponseWriter. Use it to send the <head> of a page before rendering slow sections.
+//
+//	{! templ.Flush() }
+func Flush() Component {
+	return ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		return flush(w)
+	})
+}
+
+// flush the writer if it's a http.Flusher, or has a Flush method, e.g. bufio.Writer. Writers that
+// wrap a http.ResponseWriter, e.g. in middleware, are unwrapped to find a http.Flusher.
+func flush(w io.Writer) error {
+	for {
+		switch f := w.(type) {
+		case http.Flusher:
+			f.Flush()
+			return nil
+		case interface{ Flush() error }:
+			return f.Flush()
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = f.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
 // EscapeString escapes HTML text within templates.
 func EscapeString(s string) string {
 	return html.EscapeString(s)
@@ -124,6 +292,228 @@ func Bool(value bool) bool {
 	return value
 }
 
+// Spread attributes.
+
+// Attributes is a map of attribute names to values that can be spread onto
+// an element using the { attrs... } syntax.
+//
+// Values can be a string, bool, templ.SafeURL or templ.ComponentScript. Any
+// other value is converted to a string using fmt.Sprint.
+type Attributes map[string]interface{}
+
+// names returns the attribute names in sorted order, so that output is deterministic.
+func (a Attributes) names() []string {
+	names := make([]string, len(a))
+	var index int
+	for k := range a {
+		names[index] = k
+		index++
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Scripts returns the scripts used by on* event handler attributes.
+func (a Attributes) Scripts() (scripts []ComponentScript) {
+	for _, name := range a.names() {
+		if script, ok := a[name].(ComponentScript); ok && isEventHandlerAttribute(name) {
+			scripts = append(scripts, script)
+		}
+	}
+	return scripts
+}
+
+// RenderAttributes renders attributes, sorted by name.
+//
+// Attributes with invalid names are skipped. Values are escaped according to the
+// attribute's context, see AttributeContextOf. URL values are sanitized unless
+// they're a templ.SafeURL, style values are sanitized unless they're a
+// templ.SafeCSS, and on* event handler attributes are only rendered if the value
+// is a templ.ComponentScript. The scripts themselves must be rendered before the
+// element, see Attributes.Scripts.
+func RenderAttributes(ctx context.Context, w io.Writer, attributes Attributes) (err error) {
+	for _, name := range attributes.names() {
+		if !isValidAttributeName(name) {
+			continue
+		}
+		attributeContext := AttributeContextOf(name)
+		var value string
+		switch v := attributes[name].(type) {
+		case bool:
+			if !v {
+				continue
+			}
+			if _, err = io.WriteString(w, " "+EscapeString(name)); err != nil {
+				return err
+			}
+			continue
+		case ComponentScript:
+			if attributeContext != AttributeContextJS {
+				continue
+			}
+			if _, err = io.WriteString(w, " "+EscapeString(name)+"=\""+v.Call+"\""); err != nil {
+				return err
+			}
+			continue
+		default:
+			switch attributeContext {
+			case AttributeContextJS:
+				// Event handlers can only be set using script templates.
+				continue
+			case AttributeContextURL:
+				value = string(SanitizeURL(v))
+			case AttributeContextSrcset:
+				value = string(SanitizeSrcset(v))
+			case AttributeContextCSS:
+				value = string(sanitizeStyle(v))
+			default:
+				value = fmt.Sprint(v)
+			}
+		}
+		if _, err = io.WriteString(w, " "+EscapeString(name)+"=\""+EscapeString(value)+"\""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isEventHandlerAttribute(name string) bool {
+	return AttributeContextOf(name) == AttributeContextJS
+}
+
+// isValidAttributeName checks the name against the HTML specification.
+// https://html.spec.whatwg.org/multipage/syntax.html#attributes-2
+func isValidAttributeName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return false
+		}
+		switch r {
+		case '"', '\'', '<', '>', '/', '=':
+			return false
+		}
+	}
+	return true
+}
+
+// Attribute escaping contexts.
+
+// AttributeContext is the escaping context of an attribute value, determined
+// by the attribute's name in the same way as html/template.
+type AttributeContext int
+
+const (
+	// AttributeContextHTML values are HTML escaped.
+	AttributeContextHTML AttributeContext = iota
+	// AttributeContextURL values are URLs, which are sanitized and HTML escaped, e.g. href and src.
+	AttributeContextURL
+	// AttributeContextSrcset values are comma separated lists of URLs and descriptors, e.g. srcset.
+	AttributeContextSrcset
+	// AttributeContextJS values are JavaScript, and can only be set using script templates, e.g. onclick.
+	AttributeContextJS
+	// AttributeContextCSS values are CSS declarations, e.g. style.
+	AttributeContextCSS
+)
+
+// urlAttributes are the attributes that contain a URL.
+// https://html.spec.whatwg.org/multipage/indices.html#attributes-3
+var urlAttributes = map[string]struct{}{
+	"action": {}, "archive": {}, "background": {}, "cite": {}, "classid": {}, "codebase": {}, "data": {}, "formaction": {}, "href": {}, "icon": {}, "longdesc": {}, "manifest": {}, "ping": {}, "poster": {}, "profile": {}, "src": {}, "usemap": {}, "xmlns": {},
+}
+
+// srcsetAttributes are the attributes that contain a list of image candidate strings.
+// https://html.spec.whatwg.org/multipage/images.html#srcset-attributes
+var srcsetAttributes = map[string]struct{}{
+	"srcset": {}, "imagesrcset": {},
+}
+
+// AttributeContextOf returns the escaping context of the named attribute.
+//
+// Like html/template, a "data-" prefix is ignored, namespaced attributes such as
+// xlink:href and framework bindings such as :src use the local name, and unknown
+// names containing "src", "uri" or "url" are treated as URLs.
+func AttributeContextOf(name string) AttributeContext {
+	name = strings.ToLower(name)
+	if strings.HasPrefix(name, "data-") {
+		name = name[len("data-"):]
+	} else if i := strings.LastIndexByte(name, ':'); i >= 0 {
+		if strings.HasPrefix(name, "xmlns:") {
+			return AttributeContextURL
+		}
+		name = name[i+1:]
+	}
+	if strings.HasPrefix(name, "on") {
+		return AttributeContextJS
+	}
+	if name == "style" {
+		return AttributeContextCSS
+	}
+	if _, ok := srcsetAttributes[name]; ok {
+		return AttributeContextSrcset
+	}
+	if _, ok := urlAttributes[name]; ok {
+		return AttributeContextURL
+	}
+	if strings.Contains(name, "src") || strings.Contains(name, "uri") || strings.Contains(name, "url") {
+		return AttributeContextURL
+	}
+	return AttributeContextHTML
+}
+
+// SanitizeURL sanitizes the value of a URL attribute. A templ.SafeURL is
+// returned unchanged, any other value is converted to a string and sanitized
+// using templ.URL.
+func SanitizeURL(value interface{}) SafeURL {
+	switch v := value.(type) {
+	case SafeURL:
+		return v
+	case string:
+		return URL(v)
+	}
+	return URL(fmt.Sprint(value))
+}
+
+// SanitizeSrcset sanitizes each URL in the value of a srcset attribute, e.g.
+// "image-1x.png 1x, image-2x.png 2x". A templ.SafeURL is returned unchanged.
+func SanitizeSrcset(value interface{}) SafeURL {
+	if v, ok := value.(SafeURL); ok {
+		return v
+	}
+	candidates := strings.Split(fmt.Sprint(value), ",")
+	for i, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		url, descriptor := candidate, ""
+		if j := strings.IndexFunc(candidate, unicode.IsSpace); j >= 0 {
+			url, descriptor = candidate[:j], candidate[j:]
+		}
+		candidates[i] = string(URL(url)) + descriptor
+	}
+	return SafeURL(strings.Join(candidates, ", "))
+}
+
+// sanitizeStyle sanitizes each declaration in the value of a style attribute.
+// A templ.SafeCSS is returned unchanged.
+func sanitizeStyle(value interface{}) SafeCSS {
+	if v, ok := value.(SafeCSS); ok {
+		return v
+	}
+	var sb strings.Builder
+	for _, declaration := range strings.Split(fmt.Sprint(value), ";") {
+		if strings.TrimSpace(declaration) == "" {
+			continue
+		}
+		property, value := declaration, ""
+		if i := strings.IndexByte(declaration, ':'); i >= 0 {
+			property, value = declaration[:i], declaration[i+1:]
+		}
+		sb.WriteString(string(SanitizeCSS(strings.TrimSpace(property), strings.TrimSpace(value))))
+	}
+	return SafeCSS(sb.String())
+}
+
 // Classes for CSS.
 func Classes(classes ...CSSClass) CSSClasses {
 	return CSSClasses(classes)
@@ -319,6 +709,14 @@ func (rc *StringSet) Contains(s string) bool {
 	return ok
 }
 
+func (rc *StringSet) clone() *StringSet {
+	ss := make(map[string]struct{}, len(rc.ss))
+	for k := range rc.ss {
+		ss[k] = struct{}{}
+	}
+	return &StringSet{ss: ss}
+}
+
 // All returns a slice of all items in the set.
 func (rc *StringSet) All() (values []string) {
 	values = make([]string, len(rc.ss))
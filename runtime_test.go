@@ -1,6 +1,7 @@
 package templ
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"errors"
@@ -9,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -230,6 +232,138 @@ func TestClassSanitization(t *testing.T) {
 
 }
 
+func TestRenderAttributes(t *testing.T) {
+	script := ComponentScript{Name: "fn", Call: "fn()"}
+	var tests = []struct {
+		name     string
+		input    Attributes
+		expected string
+	}{
+		{
+			name:     "no attributes",
+			input:    nil,
+			expected: "",
+		},
+		{
+			name:     "attributes are sorted by name",
+			input:    Attributes{"b": "2", "a": "1", "c": true},
+			expected: ` a="1" b="2" c`,
+		},
+		{
+			name:     "safe URLs are not sanitized",
+			input:    Attributes{"href": SafeURL("javascript:alert(1)")},
+			expected: ` href="javascript:alert(1)"`,
+		},
+		{
+			name:     "unsafe URLs are sanitized",
+			input:    Attributes{"HREF": "javascript:alert(1)"},
+			expected: ` HREF="about:invalid#TemplFailedSanitizationURL"`,
+		},
+		{
+			name:     "scripts are only rendered for event handlers",
+			input:    Attributes{"onClick": script, "title": script},
+			expected: ` onClick="fn()"`,
+		},
+		{
+			name:     "all URL attributes are sanitized",
+			input:    Attributes{"src": "javascript:alert(1)", "formaction": "javascript:alert(1)", "srcset": "javascript:alert(1) 2x"},
+			expected: ` formaction="about:invalid#TemplFailedSanitizationURL" src="about:invalid#TemplFailedSanitizationURL" srcset="about:invalid#TemplFailedSanitizationURL 2x"`,
+		},
+		{
+			name:     "styles are sanitized",
+			input:    Attributes{"style": "color: red; background-image: url(javascript:alert(1))"},
+			expected: ` style="color:red;background-image:zTemplUnsafeCSSPropertyValue;"`,
+		},
+		{
+			name:     "safe styles are not sanitized",
+			input:    Attributes{"style": SafeCSS("background-image: url(/image.png)")},
+			expected: ` style="background-image: url(/image.png)"`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			w := new(strings.Builder)
+			err := RenderAttributes(context.Background(), w, tt.input)
+			if err != nil {
+				t.Fatalf("failed to render attributes: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, w.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAttributeContextOf(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected AttributeContext
+	}{
+		{name: "title", expected: AttributeContextHTML},
+		{name: "href", expected: AttributeContextURL},
+		{name: "SRC", expected: AttributeContextURL},
+		{name: "formaction", expected: AttributeContextURL},
+		{name: "data", expected: AttributeContextURL},
+		{name: "data-url", expected: AttributeContextURL},
+		{name: "data-title", expected: AttributeContextHTML},
+		{name: "xlink:href", expected: AttributeContextURL},
+		{name: "xmlns:svg", expected: AttributeContextURL},
+		{name: ":src", expected: AttributeContextURL},
+		{name: "x-bind:title", expected: AttributeContextHTML},
+		{name: "srcset", expected: AttributeContextSrcset},
+		{name: "imagesrcset", expected: AttributeContextSrcset},
+		{name: "onclick", expected: AttributeContextJS},
+		{name: "data-onclick", expected: AttributeContextJS},
+		{name: "style", expected: AttributeContextCSS},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := AttributeContextOf(tt.name); actual != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSanitizeSrcset(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    interface{}
+		expected SafeURL
+	}{
+		{
+			name:     "single URL",
+			input:    "/image.png",
+			expected: "/image.png",
+		},
+		{
+			name:     "URLs and descriptors",
+			input:    "/image-1x.png 1x,/image-2x.png   2x",
+			expected: "/image-1x.png 1x, /image-2x.png   2x",
+		},
+		{
+			name:     "unsafe URLs are replaced",
+			input:    "/image.png 100w, javascript:alert(1) 200w",
+			expected: "/image.png 100w, about:invalid#TemplFailedSanitizationURL 200w",
+		},
+		{
+			name:     "safe URLs are not sanitized",
+			input:    SafeURL("javascript:alert(1) 1x"),
+			expected: "javascript:alert(1) 1x",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := SanitizeSrcset(tt.input); actual != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
 func TestHandler(t *testing.T) {
 	hello := ComponentFunc(func(ctx context.Context, w io.Writer) error {
 		io.WriteString(w, "Hello")
@@ -238,12 +372,17 @@ func TestHandler(t *testing.T) {
 	errorComponent := ComponentFunc(func(ctx context.Context, w io.Writer) error {
 		return errors.New("handler error")
 	})
+	partialErrorComponent := ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		io.WriteString(w, "Hello")
+		return errors.New("handler error")
+	})
 
 	var tests = []struct {
-		name           string
-		input          *ComponentHandler
-		expectedStatus int
-		expectedBody   string
+		name            string
+		input           *ComponentHandler
+		expectedStatus  int
+		expectedHeaders http.Header
+		expectedBody    string
 	}{
 		{
 			name:           "handlers return OK by default",
@@ -276,6 +415,51 @@ func TestHandler(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   "custom body",
 		},
+		{
+			name:           "the content type is set when an alternative status code is returned",
+			input:          Handler(hello, WithStatus(http.StatusNotFound), WithContentType("text/plain")),
+			expectedStatus: http.StatusNotFound,
+			expectedHeaders: http.Header{
+				"Content-Type": []string{"text/plain"},
+			},
+			expectedBody: "Hello",
+		},
+		{
+			name:           "unbuffered handlers that fail part way through write the partial output",
+			input:          Handler(partialErrorComponent),
+			expectedStatus: http.StatusOK,
+			expectedBody:   "Hello" + componentHandlerErrorMessage + "\n",
+		},
+		{
+			name:           "buffered handlers set the content length",
+			input:          Handler(hello, WithBuffering(), WithStatus(http.StatusNotFound)),
+			expectedStatus: http.StatusNotFound,
+			expectedHeaders: http.Header{
+				"Content-Type":   []string{"text/html"},
+				"Content-Length": []string{"5"},
+			},
+			expectedBody: "Hello",
+		},
+		{
+			name:           "buffered handlers that fail part way through only write the error",
+			input:          Handler(partialErrorComponent, WithBuffering(), WithStatus(http.StatusNotFound)),
+			expectedStatus: http.StatusInternalServerError,
+			expectedHeaders: http.Header{
+				"Content-Type": []string{"text/plain; charset=utf-8"},
+			},
+			expectedBody: componentHandlerErrorMessage + "\n",
+		},
+		{
+			name: "buffered handlers that fail part way through only write the custom error",
+			input: Handler(partialErrorComponent, WithBuffering(), WithErrorHandler(func(r *http.Request, err error) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusBadRequest)
+					io.WriteString(w, "custom body")
+				})
+			})),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "custom body",
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -286,6 +470,11 @@ func TestHandler(t *testing.T) {
 			if got := w.Result().StatusCode; tt.expectedStatus != got {
 				t.Errorf("expected status %d, got %d", tt.expectedStatus, got)
 			}
+			for name := range tt.expectedHeaders {
+				if diff := cmp.Diff(tt.expectedHeaders.Values(name), w.Result().Header.Values(name)); diff != "" {
+					t.Errorf("%s: %s", name, diff)
+				}
+			}
 			body, err := io.ReadAll(w.Result().Body)
 			if err != nil {
 				t.Errorf("failed to read body: %v", err)
@@ -296,3 +485,164 @@ func TestHandler(t *testing.T) {
 		})
 	}
 }
+
+type wrappedResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w wrappedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func TestFlush(t *testing.T) {
+	t.Run("http.Flusher writers are flushed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		if err := Flush().Render(context.Background(), w); err != nil {
+			t.Fatalf("failed to render: %v", err)
+		}
+		if !w.Flushed {
+			t.Error("expected the writer to be flushed")
+		}
+	})
+	t.Run("wrapped http.ResponseWriters are flushed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		if err := Flush().Render(context.Background(), wrappedResponseWriter{w}); err != nil {
+			t.Fatalf("failed to render: %v", err)
+		}
+		if !w.Flushed {
+			t.Error("expected the writer to be flushed")
+		}
+	})
+	t.Run("writers with a Flush method are flushed", func(t *testing.T) {
+		var output strings.Builder
+		w := bufio.NewWriter(&output)
+		io.WriteString(w, "Hello")
+		if err := Flush().Render(context.Background(), w); err != nil {
+			t.Fatalf("failed to render: %v", err)
+		}
+		if diff := cmp.Diff("Hello", output.String()); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("other writers are ignored", func(t *testing.T) {
+		if err := Flush().Render(context.Background(), new(bytes.Buffer)); err != nil {
+			t.Errorf("failed to render: %v", err)
+		}
+	})
+}
+
+func TestStreamingHandler(t *testing.T) {
+	proceed := make(chan struct{})
+	defer close(proceed)
+	c := ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		io.WriteString(w, "<head></head>")
+		if err := Flush().Render(ctx, w); err != nil {
+			return err
+		}
+		// Wait for the client to receive the first part of the response.
+		select {
+		case <-proceed:
+		case <-ctx.Done():
+		}
+		_, err := io.WriteString(w, "<body></body>")
+		return err
+	})
+	s := httptest.NewServer(Handler(c, WithStreaming(), WithStatus(http.StatusAccepted)))
+	defer s.Close()
+
+	resp, err := http.Get(s.URL)
+	if err != nil {
+		t.Fatalf("failed to get: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("expected status %d, got %d", http.StatusAccepted, resp.StatusCode)
+	}
+	if diff := cmp.Diff([]string{"chunked"}, resp.TransferEncoding); diff != "" {
+		t.Error(diff)
+	}
+	head := make(chan string)
+	go func() {
+		b := make([]byte, len("<head></head>"))
+		io.ReadFull(resp.Body, b)
+		head <- string(b)
+	}()
+	select {
+	case actual := <-head:
+		if diff := cmp.Diff("<head></head>", actual); diff != "" {
+			t.Error(diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the flushed output")
+	}
+	proceed <- struct{}{}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if diff := cmp.Diff("<body></body>", string(body)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestHandlerPanicRecovery(t *testing.T) {
+	panicking := ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		var p *struct{ Name string }
+		_, err := io.WriteString(w, p.Name)
+		return err
+	})
+
+	t.Run("panics are passed to the error handler", func(t *testing.T) {
+		var handled error
+		h := Handler(panicking, WithPanicRecovery(), WithErrorHandler(func(r *http.Request, err error) http.Handler {
+			handled = err
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			})
+		}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		if w.Code != http.StatusTeapot {
+			t.Errorf("expected status %d, got %d", http.StatusTeapot, w.Code)
+		}
+		var pe PanicError
+		if !errors.As(handled, &pe) {
+			t.Fatalf("expected a PanicError, got %v", handled)
+		}
+		if _, ok := pe.Value.(error); !ok {
+			t.Errorf("expected the runtime error to be the panic value, got %v", pe.Value)
+		}
+	})
+	t.Run("panics are reported", func(t *testing.T) {
+		var reported []error
+		h := Handler(panicking, WithPanicRecovery(), WithErrorReporter(ErrorReporterFunc(func(r *http.Request, err error) {
+			reported = append(reported, err)
+		})))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected status %d, got %d", http.StatusInternalServerError, w.Code)
+		}
+		if len(reported) != 1 {
+			t.Fatalf("expected 1 error to be reported, got %v", reported)
+		}
+		if !errors.As(reported[0], new(PanicError)) {
+			t.Errorf("expected a PanicError, got %v", reported[0])
+		}
+	})
+	t.Run("errors handled by error boundaries are reported", func(t *testing.T) {
+		var reported []error
+		c := ErrorBoundary(panicking, func(err error) Component { return NopComponent })
+		h := Handler(c, WithErrorReporter(ErrorReporterFunc(func(r *http.Request, err error) {
+			reported = append(reported, err)
+		})))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if len(reported) != 1 {
+			t.Fatalf("expected 1 error to be reported, got %v", reported)
+		}
+	})
+}
@@ -18,22 +18,7 @@ func headerTemplate(name string) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
-		_, err = io.WriteString(w, "<header")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " data-testid=\"headerTemplate\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<h1>")
+		_, err = io.WriteString(w, `<header data-testid="headerTemplate"><h1>`)
 		if err != nil {
 			return err
 		}
@@ -42,11 +27,7 @@ func headerTemplate(name string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</h1>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</header>")
+		_, err = io.WriteString(w, `</h1></header>`)
 		if err != nil {
 			return err
 		}
@@ -60,28 +41,7 @@ func footerTemplate() templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_2 := ctx
 		ctx = templ.ClearChildren(var_2)
-		// Element (standard)
-		_, err = io.WriteString(w, "<footer")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " data-testid=\"footerTemplate\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<div>")
-		if err != nil {
-			return err
-		}
-		// Text
-		var_3 := `&copy; `
-		_, err = io.WriteString(w, var_3)
+		_, err = io.WriteString(w, `<footer data-testid="footerTemplate"><div>&copy; `)
 		if err != nil {
 			return err
 		}
@@ -90,11 +50,7 @@ func footerTemplate() templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</div>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</footer>")
+		_, err = io.WriteString(w, `</div></footer>`)
 		if err != nil {
 			return err
 		}
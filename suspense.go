@@ -0,0 +1,156 @@
+package templ
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// Suspense renders the fallback component in place of the loader component, and starts rendering
+// the loader concurrently. When the page has been rendered, the output of each loader is appended to
+// the response as it completes, with a script that replaces the fallback. This allows slow sections
+// of a page to load in parallel over a single response.
+//
+// The ComponentHandler waits for the loaders to complete before the response ends. To render
+// components outside of a ComponentHandler, use SuspendedFromContext, and render the Suspended
+// sections after the page. If there are no Suspended sections in the context, the loader is rendered
+// in place of the fallback.
+//
+// The fallback is wrapped in a <div> element, so Suspense can't be used where a <div> isn't allowed,
+// e.g. within a <table> element. Scripts within the loader output are not run. Suspense components
+// within a loader are rendered in place.
+func Suspense(fallback, loader Component) Component {
+	return ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		s, ok := ctx.Value(contextKeySuspended).(*Suspended)
+		if !ok || s == nil {
+			return loader.Render(ctx, w)
+		}
+		id := s.start(suspenseContext(ctx), loader)
+		if _, err := io.WriteString(w, `<div id="`+id+`" style="display:contents">`); err != nil {
+			return err
+		}
+		if err := fallback.Render(ctx, w); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, `</div>`)
+		return err
+	})
+}
+
+// suspenseContext creates the context used to render a loader. Loaders are rendered concurrently, so
+// they're given their own copies of the rendered CSS classes and scripts.
+func suspenseContext(ctx context.Context) context.Context {
+	_, classes := RenderedCSSClassesFromContext(ctx)
+	_, scripts := RenderedScriptsFromContext(ctx)
+	ctx = context.WithValue(ctx, contextKeyRenderedClasses, classes.clone())
+	ctx = context.WithValue(ctx, contextKeyRenderedScripts, scripts.clone())
+	return context.WithValue(ctx, contextKeySuspended, (*Suspended)(nil))
+}
+
+type suspenseContextKey string
+
+var contextKeySuspended = suspenseContextKey("suspended")
+
+// SuspendedFromContext returns the Suspense sections that are rendering for the response.
+func SuspendedFromContext(ctx context.Context) (context.Context, *Suspended) {
+	if s, ok := ctx.Value(contextKeySuspended).(*Suspended); ok && s != nil {
+		return ctx, s
+	}
+	s := &Suspended{notify: make(chan struct{}, 1)}
+	ctx = context.WithValue(ctx, contextKeySuspended, s)
+	return ctx, s
+}
+
+// Suspended is the set of Suspense sections that are rendering for a response.
+type Suspended struct {
+	m         sync.Mutex
+	count     int
+	rendered  int
+	completed []suspendedSection
+	// notify receives a value when a section completes.
+	notify chan struct{}
+}
+
+type suspendedSection struct {
+	id     string
+	output *bytes.Buffer
+	err    error
+}
+
+func (s *Suspended) start(ctx context.Context, loader Component) (id string) {
+	s.m.Lock()
+	s.count++
+	id = "templ-suspense-" + strconv.Itoa(s.count)
+	s.m.Unlock()
+	go func() {
+		output := new(bytes.Buffer)
+		// A panic in a goroutine can't be recovered by the caller, so it's returned as an error.
+		err := renderRecover(ctx, loader, output)
+		s.m.Lock()
+		s.completed = append(s.completed, suspendedSection{id: id, output: output, err: err})
+		s.m.Unlock()
+		select {
+		case s.notify <- struct{}{}:
+		default:
+		}
+	}()
+	return id
+}
+
+// Render waits for the Suspense sections to complete, and renders each one, in the order that they
+// complete, with a script that replaces its fallback. The output is flushed after each section.
+func (s *Suspended) Render(ctx context.Context, w io.Writer) error {
+	s.m.Lock()
+	pending := s.count > s.rendered
+	s.m.Unlock()
+	if !pending {
+		return nil
+	}
+	// Send the page, including the fallbacks, before waiting.
+	if err := flush(w); err != nil {
+		return err
+	}
+	for {
+		s.m.Lock()
+		completed := s.completed
+		s.completed = nil
+		s.rendered += len(completed)
+		done := s.rendered == s.count
+		s.m.Unlock()
+		for _, section := range completed {
+			if section.err != nil {
+				return section.err
+			}
+			if err := renderSuspendedSection(w, section); err != nil {
+				return err
+			}
+			if err := flush(w); err != nil {
+				return err
+			}
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.notify:
+		}
+	}
+}
+
+func renderSuspendedSection(w io.Writer, section suspendedSection) (err error) {
+	if _, err = io.WriteString(w, `<template id="`+section.id+`-content">`); err != nil {
+		return err
+	}
+	if _, err = section.output.WriteTo(w); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, `</template><script type="text/javascript">(function(id){`+
+		`var f=document.getElementById(id),t=document.getElementById(id+"-content");`+
+		`if(f&&t){f.replaceWith(t.content);t.remove();}`+
+		`})("`+section.id+`")</script>`)
+	return err
+}
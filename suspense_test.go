@@ -0,0 +1,131 @@
+package templ
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func suspendedSectionOutput(id, contents string) string {
+	var sb strings.Builder
+	renderSuspendedSection(&sb, suspendedSection{id: id, output: bytes.NewBufferString(contents)})
+	return sb.String()
+}
+
+func text(s string) Component {
+	return ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		_, err := io.WriteString(w, s)
+		return err
+	})
+}
+
+func TestSuspense(t *testing.T) {
+	t.Run("loaders are rendered in place without Suspended sections in the context", func(t *testing.T) {
+		var sb strings.Builder
+		err := Suspense(text("Loading"), text("Loaded")).Render(context.Background(), &sb)
+		if err != nil {
+			t.Fatalf("failed to render: %v", err)
+		}
+		if diff := cmp.Diff("Loaded", sb.String()); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("loaders are rendered after the page, in the order that they complete", func(t *testing.T) {
+		firstCanComplete := make(chan struct{})
+		first := ComponentFunc(func(ctx context.Context, w io.Writer) error {
+			<-firstCanComplete
+			_, err := io.WriteString(w, "First")
+			return err
+		})
+		second := ComponentFunc(func(ctx context.Context, w io.Writer) error {
+			defer close(firstCanComplete)
+			_, err := io.WriteString(w, "Second")
+			return err
+		})
+		page := ComponentFunc(func(ctx context.Context, w io.Writer) error {
+			io.WriteString(w, "<main>")
+			Suspense(text("Loading first"), first).Render(ctx, w)
+			Suspense(text("Loading second"), second).Render(ctx, w)
+			_, err := io.WriteString(w, "</main>")
+			return err
+		})
+
+		w := httptest.NewRecorder()
+		Handler(page).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		expected := `<main>` +
+			`<div id="templ-suspense-1" style="display:contents">Loading first</div>` +
+			`<div id="templ-suspense-2" style="display:contents">Loading second</div>` +
+			`</main>` +
+			suspendedSectionOutput("templ-suspense-2", "Second") +
+			suspendedSectionOutput("templ-suspense-1", "First")
+		if diff := cmp.Diff(expected, w.Body.String()); diff != "" {
+			t.Error(diff)
+		}
+		if !w.Flushed {
+			t.Error("expected the page to be flushed before the loaders complete")
+		}
+	})
+	t.Run("Suspense components within loaders are rendered in place", func(t *testing.T) {
+		page := Suspense(text("Loading"), Suspense(text("Loading nested"), text("Nested")))
+
+		w := httptest.NewRecorder()
+		Handler(page).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		expected := `<div id="templ-suspense-1" style="display:contents">Loading</div>` +
+			suspendedSectionOutput("templ-suspense-1", "Nested")
+		if diff := cmp.Diff(expected, w.Body.String()); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("loaders render CSS that the page has not rendered", func(t *testing.T) {
+		pageClass := ComponentCSSClass{ID: "page", Class: SafeCSS(".page{}")}
+		loaderClass := ComponentCSSClass{ID: "loader", Class: SafeCSS(".loader{}")}
+		loader := ComponentFunc(func(ctx context.Context, w io.Writer) error {
+			return RenderCSS(ctx, w, []CSSClass{pageClass, loaderClass})
+		})
+		page := ComponentFunc(func(ctx context.Context, w io.Writer) error {
+			ctx, _ = RenderedCSSClassesFromContext(ctx)
+			if err := RenderCSS(ctx, w, []CSSClass{pageClass}); err != nil {
+				return err
+			}
+			if err := Suspense(NopComponent, loader).Render(ctx, w); err != nil {
+				return err
+			}
+			return RenderCSS(ctx, w, []CSSClass{pageClass, loaderClass})
+		})
+
+		w := httptest.NewRecorder()
+		Handler(page).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		expected := `<style type="text/css">.page{}</style>` +
+			`<div id="templ-suspense-1" style="display:contents"></div>` +
+			`<style type="text/css">.loader{}</style>` +
+			suspendedSectionOutput("templ-suspense-1", `<style type="text/css">.loader{}</style>`)
+		if diff := cmp.Diff(expected, w.Body.String()); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("loader errors are handled by the ComponentHandler", func(t *testing.T) {
+		page := Suspense(text("Loading"), ComponentFunc(func(ctx context.Context, w io.Writer) error {
+			return errors.New("loader error")
+		}))
+
+		w := httptest.NewRecorder()
+		Handler(page, WithBuffering()).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected status %d, got %d", http.StatusInternalServerError, w.Code)
+		}
+		if diff := cmp.Diff(componentHandlerErrorMessage+"\n", w.Body.String()); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
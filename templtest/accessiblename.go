@@ -0,0 +1,167 @@
+package templtest
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// AccessibleName returns the name of the first element in the selection that's presented to users of
+// assistive technology, such as screen readers. It's a simplified version of the Accessible Name
+// computation (https://www.w3.org/TR/accname-1.2/) that uses, in order:
+//
+//   - The text of the elements referred to by aria-labelledby.
+//   - The aria-label attribute.
+//   - The label of form controls, the value of input buttons, and the alt attribute of images.
+//   - The legend of fieldsets, caption of tables and figcaption of figures.
+//   - The text of links, buttons, headings and other elements that are named by their contents.
+//   - The title attribute.
+//
+// CSS isn't taken into account, so elements hidden by CSS are included.
+func AccessibleName(sel *goquery.Selection) string {
+	sel = sel.First()
+	root := sel.Parents().Last()
+	if root.Length() == 0 {
+		root = sel
+	}
+	return normaliseSpace(accessibleName(root, sel))
+}
+
+// nameFromContentRoles are the roles of elements that are named by their contents.
+var nameFromContentRoles = map[string]bool{
+	"button":       true,
+	"cell":         true,
+	"checkbox":     true,
+	"columnheader": true,
+	"gridcell":     true,
+	"heading":      true,
+	"link":         true,
+	"menuitem":     true,
+	"option":       true,
+	"radio":        true,
+	"row":          true,
+	"rowheader":    true,
+	"switch":       true,
+	"tab":          true,
+	"tooltip":      true,
+	"treeitem":     true,
+}
+
+// nameFromContentElements are the elements that have a role that's named by their contents.
+var nameFromContentElements = map[string]bool{
+	"a":       true,
+	"button":  true,
+	"h1":      true,
+	"h2":      true,
+	"h3":      true,
+	"h4":      true,
+	"h5":      true,
+	"h6":      true,
+	"option":  true,
+	"summary": true,
+	"td":      true,
+	"th":      true,
+}
+
+func accessibleName(root, sel *goquery.Selection) string {
+	if ids := strings.Fields(sel.AttrOr("aria-labelledby", "")); len(ids) > 0 {
+		var names []string
+		for _, id := range ids {
+			if label := root.Find(`[id="` + id + `"]`); label.Length() > 0 {
+				names = append(names, textAlternative(label.First()))
+			}
+		}
+		if name := strings.Join(names, " "); strings.TrimSpace(name) != "" {
+			return name
+		}
+	}
+	if label := sel.AttrOr("aria-label", ""); strings.TrimSpace(label) != "" {
+		return label
+	}
+	switch goquery.NodeName(sel) {
+	case "input":
+		switch strings.ToLower(sel.AttrOr("type", "")) {
+		case "button", "submit", "reset":
+			if value, ok := sel.Attr("value"); ok {
+				return value
+			}
+		case "image":
+			if alt, ok := sel.Attr("alt"); ok {
+				return alt
+			}
+		}
+		if name := labelText(root, sel); name != "" {
+			return name
+		}
+	case "select", "textarea":
+		if name := labelText(root, sel); name != "" {
+			return name
+		}
+	case "img", "area":
+		if alt, ok := sel.Attr("alt"); ok {
+			return alt
+		}
+	case "fieldset":
+		if name := textAlternative(sel.ChildrenFiltered("legend").First()); name != "" {
+			return name
+		}
+	case "table":
+		if name := textAlternative(sel.ChildrenFiltered("caption").First()); name != "" {
+			return name
+		}
+	case "figure":
+		if name := textAlternative(sel.ChildrenFiltered("figcaption").First()); name != "" {
+			return name
+		}
+	}
+	if nameFromContentRoles[sel.AttrOr("role", "")] || nameFromContentElements[goquery.NodeName(sel)] {
+		if name := textAlternative(sel); strings.TrimSpace(name) != "" {
+			return name
+		}
+	}
+	return sel.AttrOr("title", "")
+}
+
+// labelText returns the text of the <label> elements of a form control.
+func labelText(root, sel *goquery.Selection) string {
+	var names []string
+	if id, ok := sel.Attr("id"); ok && id != "" {
+		root.Find(`label[for="` + id + `"]`).Each(func(i int, label *goquery.Selection) {
+			names = append(names, textAlternative(label))
+		})
+	}
+	if label := sel.Closest("label"); label.Length() > 0 {
+		names = append(names, textAlternative(label))
+	}
+	return strings.TrimSpace(strings.Join(names, " "))
+}
+
+// textAlternative returns the text of the selection, excluding hidden elements, and using the
+// alternative text of images and elements with an aria-label.
+func textAlternative(sel *goquery.Selection) string {
+	var sb strings.Builder
+	sel.Contents().Each(func(i int, child *goquery.Selection) {
+		switch goquery.NodeName(child) {
+		case "#text":
+			sb.WriteString(child.Text())
+			return
+		case "#comment", "script", "style", "template", "input", "select", "textarea":
+			return
+		case "img", "area":
+			sb.WriteString(child.AttrOr("alt", ""))
+			return
+		}
+		if child.AttrOr("aria-hidden", "") == "true" {
+			return
+		}
+		if _, hidden := child.Attr("hidden"); hidden {
+			return
+		}
+		if label := child.AttrOr("aria-label", ""); strings.TrimSpace(label) != "" {
+			sb.WriteString(label)
+			return
+		}
+		sb.WriteString(textAlternative(child))
+	})
+	return sb.String()
+}
@@ -0,0 +1,168 @@
+// Package templtest renders templ components in tests, and provides assertions on the output.
+//
+//	func TestHeader(t *testing.T) {
+//		doc := templtest.Render(t, header("Posts"))
+//		doc.AssertExists(templtest.ByTestID("header"))
+//		doc.AssertText("h1", "Posts")
+//		doc.AssertGolden("testdata/header.html")
+//	}
+package templtest
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/a-h/templ"
+	"github.com/google/go-cmp/cmp"
+)
+
+var update = flag.Bool("templtest.update", false, "Set to true to update golden files with the rendered output, e.g. go test -templtest.update")
+
+// Document is the rendered output of a component. The embedded goquery.Document can be used to query the output.
+type Document struct {
+	*goquery.Document
+	// HTML rendered by the component.
+	HTML string
+	t    testing.TB
+}
+
+// Render the component with a background context. The test fails if rendering fails.
+func Render(t testing.TB, c templ.Component) *Document {
+	t.Helper()
+	return RenderContext(t, context.Background(), c)
+}
+
+// RenderContext renders the component with the context. The test fails if rendering fails.
+func RenderContext(t testing.TB, ctx context.Context, c templ.Component) *Document {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := c.Render(ctx, &buf); err != nil {
+		t.Fatalf("failed to render component: %v", err)
+	}
+	html := buf.String()
+	doc, err := goquery.NewDocumentFromReader(&buf)
+	if err != nil {
+		t.Fatalf("failed to parse rendered component: %v", err)
+	}
+	return &Document{
+		Document: doc,
+		HTML:     html,
+		t:        t,
+	}
+}
+
+// ByTestID returns a selector for elements with the data-testid attribute set to id.
+func ByTestID(id string) string {
+	return `[data-testid="` + id + `"]`
+}
+
+// AssertExists checks that at least one element matches the selector.
+func (d *Document) AssertExists(selector string) {
+	d.t.Helper()
+	if d.Find(selector).Length() == 0 {
+		d.t.Errorf("expected an element matching %q, but there wasn't one", selector)
+	}
+}
+
+// AssertNotExists checks that no elements match the selector.
+func (d *Document) AssertNotExists(selector string) {
+	d.t.Helper()
+	if n := d.Find(selector).Length(); n > 0 {
+		d.t.Errorf("expected no elements matching %q, found %d", selector, n)
+	}
+}
+
+// AssertCount checks the number of elements that match the selector.
+func (d *Document) AssertCount(selector string, expected int) {
+	d.t.Helper()
+	if actual := d.Find(selector).Length(); actual != expected {
+		d.t.Errorf("expected %d elements matching %q, found %d", expected, selector, actual)
+	}
+}
+
+// AssertText checks the text of the elements that match the selector. Whitespace in the text is
+// normalised, so that the indentation of the template doesn't affect the result.
+func (d *Document) AssertText(selector string, expected string) {
+	d.t.Helper()
+	sel, ok := d.find(selector)
+	if !ok {
+		return
+	}
+	if actual := normaliseSpace(sel.Text()); actual != expected {
+		d.t.Errorf("expected the text of %q to be %q, got %q", selector, expected, actual)
+	}
+}
+
+// AssertAttr checks the value of an attribute of the first element that matches the selector.
+func (d *Document) AssertAttr(selector, name, expected string) {
+	d.t.Helper()
+	sel, ok := d.find(selector)
+	if !ok {
+		return
+	}
+	actual, ok := sel.Attr(name)
+	if !ok {
+		d.t.Errorf("expected %q to have the %q attribute, but it didn't", selector, name)
+		return
+	}
+	if actual != expected {
+		d.t.Errorf("expected the %q attribute of %q to be %q, got %q", name, selector, expected, actual)
+	}
+}
+
+// AssertAccessibleName checks the accessible name of the first element that matches the selector.
+// See AccessibleName.
+func (d *Document) AssertAccessibleName(selector, expected string) {
+	d.t.Helper()
+	sel, ok := d.find(selector)
+	if !ok {
+		return
+	}
+	if actual := AccessibleName(sel); actual != expected {
+		d.t.Errorf("expected the accessible name of %q to be %q, got %q", selector, expected, actual)
+	}
+}
+
+// AssertGolden compares the rendered HTML with the contents of the golden file. If the test is run
+// with the -templtest.update flag, the golden file is updated instead.
+func (d *Document) AssertGolden(fileName string) {
+	d.t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			d.t.Fatalf("failed to create golden file directory: %v", err)
+			return
+		}
+		if err := os.WriteFile(fileName, []byte(d.HTML), 0644); err != nil {
+			d.t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(fileName)
+	if err != nil {
+		d.t.Fatalf("failed to read golden file, run the test with -templtest.update to create it: %v", err)
+		return
+	}
+	if diff := cmp.Diff(string(expected), d.HTML); diff != "" {
+		d.t.Errorf("rendered output doesn't match %s, run the test with -templtest.update to update it:\n%s", fileName, diff)
+	}
+}
+
+func (d *Document) find(selector string) (sel *goquery.Selection, ok bool) {
+	d.t.Helper()
+	sel = d.Find(selector)
+	if sel.Length() == 0 {
+		d.t.Errorf("expected an element matching %q, but there wasn't one", selector)
+		return sel, false
+	}
+	return sel, true
+}
+
+func normaliseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,241 @@
+package templtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/a-h/templ"
+	"github.com/google/go-cmp/cmp"
+)
+
+// recorder records test failures, so that assertions can be tested.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func html(s string) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		_, err := io.WriteString(w, s)
+		return err
+	})
+}
+
+const page = `<div data-testid="posts">
+	<h1 class="title">
+		My   posts
+	</h1>
+	<ul>
+		<li><a href="/posts/1">First</a></li>
+		<li><a href="/posts/2">Second</a></li>
+	</ul>
+</div>`
+
+func TestAssertions(t *testing.T) {
+	tests := []struct {
+		name             string
+		assert           func(d *Document)
+		expectedFailures []string
+	}{
+		{
+			name: "AssertExists passes when elements match",
+			assert: func(d *Document) {
+				d.AssertExists(ByTestID("posts"))
+			},
+		},
+		{
+			name: "AssertExists fails when no elements match",
+			assert: func(d *Document) {
+				d.AssertExists(ByTestID("missing"))
+			},
+			expectedFailures: []string{`expected an element matching "[data-testid=\"missing\"]", but there wasn't one`},
+		},
+		{
+			name: "AssertNotExists fails when elements match",
+			assert: func(d *Document) {
+				d.AssertNotExists("li")
+			},
+			expectedFailures: []string{`expected no elements matching "li", found 2`},
+		},
+		{
+			name: "AssertCount checks the number of matching elements",
+			assert: func(d *Document) {
+				d.AssertCount("li", 2)
+				d.AssertCount("a", 3)
+			},
+			expectedFailures: []string{`expected 3 elements matching "a", found 2`},
+		},
+		{
+			name: "AssertText normalises whitespace",
+			assert: func(d *Document) {
+				d.AssertText("h1", "My posts")
+				d.AssertText("ul", "First")
+			},
+			expectedFailures: []string{`expected the text of "ul" to be "First", got "First Second"`},
+		},
+		{
+			name: "AssertAttr checks the first matching element",
+			assert: func(d *Document) {
+				d.AssertAttr("a", "href", "/posts/1")
+				d.AssertAttr("h1", "class", "heading")
+				d.AssertAttr("h1", "id", "")
+			},
+			expectedFailures: []string{
+				`expected the "class" attribute of "h1" to be "heading", got "title"`,
+				`expected "h1" to have the "id" attribute, but it didn't`,
+			},
+		},
+		{
+			name: "AssertAccessibleName checks the name of the first matching element",
+			assert: func(d *Document) {
+				d.AssertAccessibleName("a", "First")
+				d.AssertAccessibleName("h1", "Posts")
+			},
+			expectedFailures: []string{`expected the accessible name of "h1" to be "Posts", got "My posts"`},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			tt.assert(Render(r, html(page)))
+			if diff := cmp.Diff(tt.expectedFailures, r.failures); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAccessibleName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "aria-labelledby refers to other elements",
+			input:    `<span id="a">Delete</span><span id="b">post</span><button id="target" aria-labelledby="a b" aria-label="Remove">X</button>`,
+			expected: "Delete post",
+		},
+		{
+			name:     "aria-label is used before the contents",
+			input:    `<button id="target" aria-label="Close">X</button>`,
+			expected: "Close",
+		},
+		{
+			name:     "inputs use the label that refers to them",
+			input:    `<label for="target">Email address</label><input id="target" type="email" title="Email">`,
+			expected: "Email address",
+		},
+		{
+			name:     "inputs use the label that contains them",
+			input:    `<label>Remember me <input id="target" type="checkbox"></label>`,
+			expected: "Remember me",
+		},
+		{
+			name:     "input buttons use their value",
+			input:    `<input id="target" type="submit" value="Save">`,
+			expected: "Save",
+		},
+		{
+			name:     "images use their alt text",
+			input:    `<img id="target" src="logo.png" alt="templ logo">`,
+			expected: "templ logo",
+		},
+		{
+			name:     "links use their text, including the alt text of images",
+			input:    `<a id="target" href="/"><img src="home.png" alt="Home"> page<span aria-hidden="true">*</span></a>`,
+			expected: "Home page",
+		},
+		{
+			name:     "fieldsets use their legend",
+			input:    `<fieldset id="target"><legend>Address</legend><input type="text"></fieldset>`,
+			expected: "Address",
+		},
+		{
+			name:     "elements with a role that's named by its contents use their text",
+			input:    `<div id="target" role="button">Open  menu</div>`,
+			expected: "Open menu",
+		},
+		{
+			name:     "other elements use their title",
+			input:    `<div id="target" title="Summary">Text</div>`,
+			expected: "Summary",
+		},
+		{
+			name:     "elements without a name have an empty name",
+			input:    `<div id="target">Text</div>`,
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d := Render(t, html(tt.input))
+			if actual := AccessibleName(d.Find("#target")); actual != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "testdata", "page.html")
+	defer func(previous bool) {
+		*update = previous
+	}(*update)
+
+	t.Run("missing golden files fail the test", func(t *testing.T) {
+		*update = false
+		r := &recorder{TB: t}
+		Render(r, html(page)).AssertGolden(fileName)
+		if len(r.failures) != 1 {
+			t.Errorf("expected 1 failure, got %v", r.failures)
+		}
+	})
+	t.Run("golden files are created when updating", func(t *testing.T) {
+		*update = true
+		r := &recorder{TB: t}
+		Render(r, html(page)).AssertGolden(fileName)
+		if len(r.failures) != 0 {
+			t.Errorf("expected no failures, got %v", r.failures)
+		}
+		actual, err := os.ReadFile(fileName)
+		if err != nil {
+			t.Fatalf("failed to read golden file: %v", err)
+		}
+		if diff := cmp.Diff(page, string(actual)); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("matching output passes", func(t *testing.T) {
+		*update = false
+		r := &recorder{TB: t}
+		Render(r, html(page)).AssertGolden(fileName)
+		if len(r.failures) != 0 {
+			t.Errorf("expected no failures, got %v", r.failures)
+		}
+	})
+	t.Run("different output fails", func(t *testing.T) {
+		*update = false
+		r := &recorder{TB: t}
+		Render(r, html(`<div></div>`)).AssertGolden(fileName)
+		if len(r.failures) != 1 {
+			t.Errorf("expected 1 failure, got %v", r.failures)
+		}
+	})
+}
@@ -14,33 +14,16 @@ func actionTemplate(action string, target string) templ.Component {
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
 		var_1 := ctx
 		ctx = templ.ClearChildren(var_1)
-		// Element (standard)
-		_, err = io.WriteString(w, "<turbo-stream")
+		_, err = io.WriteString(w, `<turbo-stream action="`)
 		if err != nil {
 			return err
 		}
-		// Element Attributes
-		_, err = io.WriteString(w, " action=")
+		var var_2 templ.SafeURL = templ.SanitizeURL(action)
+		_, err = io.WriteString(w, templ.EscapeString(string(var_2)))
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, templ.EscapeString(action))
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, " target=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, `" target="`)
 		if err != nil {
 			return err
 		}
@@ -48,16 +31,7 @@ func actionTemplate(action string, target string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		// Element (standard)
-		_, err = io.WriteString(w, "<template>")
+		_, err = io.WriteString(w, `"><template>`)
 		if err != nil {
 			return err
 		}
@@ -66,11 +40,7 @@ func actionTemplate(action string, target string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "</template>")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</turbo-stream>")
+		_, err = io.WriteString(w, `</template></turbo-stream>`)
 		if err != nil {
 			return err
 		}
@@ -82,35 +52,18 @@ func removeTemplate(action string, target string) templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) (err error) {
 		ctx, _ = templ.RenderedCSSClassesFromContext(ctx)
 		ctx, _ = templ.RenderedScriptsFromContext(ctx)
-		var_2 := ctx
-		ctx = templ.ClearChildren(var_2)
-		// Element (standard)
-		_, err = io.WriteString(w, "<turbo-stream")
-		if err != nil {
-			return err
-		}
-		// Element Attributes
-		_, err = io.WriteString(w, " action=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, templ.EscapeString(action))
+		var_3 := ctx
+		ctx = templ.ClearChildren(var_3)
+		_, err = io.WriteString(w, `<turbo-stream action="`)
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
+		var var_4 templ.SafeURL = templ.SanitizeURL(action)
+		_, err = io.WriteString(w, templ.EscapeString(string(var_4)))
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, " target=")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "\"")
+		_, err = io.WriteString(w, `" target="`)
 		if err != nil {
 			return err
 		}
@@ -118,15 +71,7 @@ func removeTemplate(action string, target string) templ.Component {
 		if err != nil {
 			return err
 		}
-		_, err = io.WriteString(w, "\"")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, ">")
-		if err != nil {
-			return err
-		}
-		_, err = io.WriteString(w, "</turbo-stream>")
+		_, err = io.WriteString(w, `"></turbo-stream>`)
 		if err != nil {
 			return err
 		}
@@ -1,4 +1,20 @@
 package templ
 
+import "runtime/debug"
+
 // Binary builds set this version string. goreleaser sets the value using Go build ldflags.
 var Version string
+
+// GetVersion returns Version if it's set. Otherwise, it returns the version of the templ module the
+// binary was built with, which is set when templ is installed using
+// `go install github.com/a-h/templ/cmd/templ@latest`, and is "(devel)" for builds of a local checkout.
+func GetVersion() string {
+	if Version != "" {
+		return Version
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	return info.Main.Version
+}